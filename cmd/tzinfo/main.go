@@ -0,0 +1,347 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command tzinfo prints the time zone information of the times package.
+//
+// Usage:
+//
+//	tzinfo [-json] local
+//	tzinfo [-json] zones <country code>
+//	tzinfo [-json] transitions [-n count] [-from time] <zone>
+//	tzinfo [-json] convert <time> <from zone> <to zone>
+//	tzinfo [-json] windows <Windows or IANA zone name>
+//
+// The zones are the IANA names, i.e. "Europe/Berlin", fixed offsets, i.e. "UTC+05:30",
+// POSIX TZ strings or the unambiguous city names and abbreviations.
+// The times are in the form "2006-01-02 15:04[:05]" or RFC 3339.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/blockysource/go-pkg/times"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+const usage = `Usage:
+  tzinfo [-json] local
+  tzinfo [-json] zones <country code>
+  tzinfo [-json] transitions [-n count] [-from time] <zone>
+  tzinfo [-json] convert <time> <from zone> <to zone>
+  tzinfo [-json] windows <Windows or IANA zone name>
+`
+
+// errUsage is returned when the command is invoked with invalid arguments.
+var errUsage = errors.New("invalid arguments")
+
+// printer writes the results either as JSON, or as text.
+type printer struct {
+	w    io.Writer
+	json bool
+}
+
+func (p *printer) print(v any, text func(w io.Writer)) error {
+	if p.json {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	text(p.w)
+	return nil
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("tzinfo", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { fmt.Fprint(stderr, usage) }
+	jsonOut := fs.Bool("json", false, "print the results as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	p := &printer{w: stdout, json: *jsonOut}
+	cmd, cmdArgs := fs.Arg(0), fs.Args()[1:]
+	var err error
+	switch cmd {
+	case "local":
+		err = runLocal(p, cmdArgs)
+	case "zones":
+		err = runZones(p, cmdArgs)
+	case "transitions":
+		err = runTransitions(p, cmdArgs, stderr)
+	case "convert":
+		err = runConvert(p, cmdArgs)
+	case "windows":
+		err = runWindows(p, cmdArgs)
+	default:
+		err = fmt.Errorf("unknown command %q: %w", cmd, errUsage)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "tzinfo %s: %v\n", cmd, err)
+		if errors.Is(err, errUsage) {
+			fmt.Fprint(stderr, usage)
+			return 2
+		}
+		return 1
+	}
+	return 0
+}
+
+type localInfo struct {
+	Zone         string `json:"zone"`
+	Source       string `json:"source"`
+	Abbreviation string `json:"abbreviation"`
+	Offset       string `json:"offset"`
+	Time         string `json:"time"`
+}
+
+func runLocal(p *printer, args []string) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+	name, source, err := times.DetectLocal()
+	if err != nil {
+		return err
+	}
+	loc, err := times.LoadLocation(name)
+	if err != nil {
+		return err
+	}
+	now := time.Now().In(loc)
+	abbr, offset := now.Zone()
+	info := localInfo{
+		Zone:         name,
+		Source:       string(source),
+		Abbreviation: abbr,
+		Offset:       formatOffset(offset),
+		Time:         now.Format(time.RFC3339),
+	}
+	return p.print(info, func(w io.Writer) {
+		fmt.Fprintf(w, "%s (%s, UTC%s)\ndetected from: %s\n", info.Zone, info.Abbreviation, info.Offset, info.Source)
+	})
+}
+
+type countryZones struct {
+	Country string   `json:"country"`
+	Name    string   `json:"name"`
+	Zones   []string `json:"zones"`
+}
+
+func runZones(p *printer, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	code := strings.ToUpper(args[0])
+	name, ok := times.Countries()[code]
+	if !ok {
+		return fmt.Errorf("unknown country code %q", args[0])
+	}
+	res := countryZones{Country: code, Name: name, Zones: times.CountryZones(code)}
+	return p.print(res, func(w io.Writer) {
+		fmt.Fprintf(w, "%s (%s):\n", res.Name, res.Country)
+		for _, z := range res.Zones {
+			fmt.Fprintf(w, "  %s\n", z)
+		}
+	})
+}
+
+type transition struct {
+	Time         string `json:"time"`
+	UTC          string `json:"utc"`
+	Abbreviation string `json:"abbreviation"`
+	Offset       string `json:"offset"`
+	DST          bool   `json:"dst"`
+}
+
+type zoneTransitions struct {
+	Zone        string       `json:"zone"`
+	Transitions []transition `json:"transitions"`
+}
+
+func runTransitions(p *printer, args []string, stderr io.Writer) error {
+	fs := flag.NewFlagSet("transitions", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	n := fs.Int("n", 4, "the number of transitions")
+	from := fs.String("from", "", "the time to list the transitions from, now by default")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if fs.NArg() != 1 || *n < 1 {
+		return errUsage
+	}
+	loc, err := resolveZone(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	if *from != "" {
+		if start, err = parseTime(*from, loc); err != nil {
+			return err
+		}
+	}
+
+	res := zoneTransitions{Zone: loc.String(), Transitions: []transition{}}
+	// The zones are searched a decade ahead, the zones without DST have no transitions.
+	trs := times.Transitions(loc, start, start.AddDate(10, 0, 0))
+	for _, tr := range trs[1:] {
+		if len(res.Transitions) == *n {
+			break
+		}
+		res.Transitions = append(res.Transitions, transition{
+			Time:         tr.At.Format(time.RFC3339),
+			UTC:          tr.At.UTC().Format(time.RFC3339),
+			Abbreviation: tr.Name,
+			Offset:       formatOffset(tr.Offset),
+			DST:          tr.IsDST,
+		})
+	}
+	return p.print(res, func(w io.Writer) {
+		if len(res.Transitions) == 0 {
+			fmt.Fprintf(w, "%s has no upcoming transitions\n", res.Zone)
+			return
+		}
+		fmt.Fprintf(w, "%s:\n", res.Zone)
+		for _, tr := range res.Transitions {
+			dst := ""
+			if tr.DST {
+				dst = ", DST"
+			}
+			fmt.Fprintf(w, "  %s  %s (UTC%s%s)\n", tr.Time, tr.Abbreviation, tr.Offset, dst)
+		}
+	})
+}
+
+type conversion struct {
+	From     string `json:"from"`
+	FromZone string `json:"from_zone"`
+	To       string `json:"to"`
+	ToZone   string `json:"to_zone"`
+}
+
+func runConvert(p *printer, args []string) error {
+	if len(args) != 3 {
+		return errUsage
+	}
+	from, err := resolveZone(args[1])
+	if err != nil {
+		return err
+	}
+	to, err := resolveZone(args[2])
+	if err != nil {
+		return err
+	}
+	t, err := parseTime(args[0], from)
+	if err != nil {
+		return err
+	}
+	res := conversion{
+		From:     t.Format(time.RFC3339),
+		FromZone: from.String(),
+		To:       t.In(to).Format(time.RFC3339),
+		ToZone:   to.String(),
+	}
+	return p.print(res, func(w io.Writer) {
+		fmt.Fprintf(w, "%s %s\n%s %s\n", res.From, res.FromZone, res.To, res.ToZone)
+	})
+}
+
+type zoneMapping struct {
+	Windows string `json:"windows"`
+	IANA    string `json:"iana"`
+}
+
+func runWindows(p *printer, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	// The Windows names contain spaces, i.e. "W. Europe Standard Time".
+	name := strings.Join(args, " ")
+	var res zoneMapping
+	if iana, ok := times.WindowsToIANA(name); ok {
+		res = zoneMapping{Windows: name, IANA: iana}
+	} else if win, ok := times.IANAToWindows(name); ok {
+		res = zoneMapping{Windows: win, IANA: name}
+	} else {
+		return fmt.Errorf("no Windows mapping of %q", name)
+	}
+	return p.print(res, func(w io.Writer) {
+		fmt.Fprintf(w, "%s <-> %s\n", res.Windows, res.IANA)
+	})
+}
+
+// resolveZone parses the zone, falling back to the unambiguous lookup by a city name or abbreviation.
+func resolveZone(s string) (*time.Location, error) {
+	loc, perr := times.ParseZone(s)
+	if perr == nil {
+		return loc, nil
+	}
+	candidates, err := times.LookupZone(s)
+	if err != nil {
+		// The parse error explains the invalid offsets, i.e. "GMT+15".
+		return nil, errors.Join(perr, err)
+	}
+	if len(candidates) > 1 {
+		names := make([]string, len(candidates))
+		for i, c := range candidates {
+			names[i] = c.Name
+		}
+		return nil, fmt.Errorf("ambiguous zone %q, did you mean one of: %s", s, strings.Join(names, ", "))
+	}
+	return candidates[0].Location, nil
+}
+
+var timeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// parseTime parses the wall clock time in the location, or the RFC 3339 time with its own offset.
+func parseTime(s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.In(loc), nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, expected \"2006-01-02 15:04[:05]\" or RFC 3339", s)
+}
+
+// formatOffset formats the offset in seconds as "+01:00".
+func formatOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	offset /= 60
+	return fmt.Sprintf("%c%02d:%02d", sign, offset/60, offset%60)
+}
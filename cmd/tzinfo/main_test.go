@@ -0,0 +1,78 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/blockysource/go-pkg/times/timestest"
+)
+
+func TestRun(t *testing.T) {
+	timestest.SetLocal(t, "Asia/Tokyo")
+
+	for _, test := range []struct {
+		args     []string
+		wantCode int
+		want     string
+	}{
+		{args: []string{"local"}, want: "Asia/Tokyo (JST, UTC+09:00)\ndetected from: override\n"},
+		{args: []string{"zones", "pl"}, want: "Poland (PL):\n  Europe/Warsaw\n"},
+		{
+			args: []string{"transitions", "-n", "2", "-from", "2023-01-01", "Europe/Berlin"},
+			want: "Europe/Berlin:\n  2023-03-26T03:00:00+02:00  CEST (UTC+02:00, DST)\n  2023-10-29T02:00:00+01:00  CET (UTC+01:00)\n",
+		},
+		{
+			args: []string{"convert", "2023-07-05 09:00", "America/New_York", "UTC+05:30"},
+			want: "2023-07-05T09:00:00-04:00 America/New_York\n2023-07-05T18:30:00+05:30 UTC+05:30\n",
+		},
+		{args: []string{"windows", "Tokyo", "Standard", "Time"}, want: "Tokyo Standard Time <-> Asia/Tokyo\n"},
+		{args: []string{"windows", "Asia/Tokyo"}, want: "Tokyo Standard Time <-> Asia/Tokyo\n"},
+		{args: []string{"zones", "XX"}, wantCode: 1},
+		{args: []string{"convert", "yesterday", "UTC", "UTC"}, wantCode: 1},
+		{args: []string{"convert", "2023-07-05 09:00", "UTC", "GMT+15"}, wantCode: 1},
+		{args: []string{"convert", "2023-07-05 09:00", "UTC+1:3", "UTC"}, wantCode: 1},
+		{args: []string{"unknown"}, wantCode: 2},
+		{args: []string{}, wantCode: 2},
+	} {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(test.args, &stdout, &stderr)
+			if code != test.wantCode {
+				t.Fatalf("exit code %d, want %d, stderr: %s", code, test.wantCode, stderr.String())
+			}
+			if code == 0 && stdout.String() != test.want {
+				t.Errorf("got:\n%s\nwant:\n%s", stdout.String(), test.want)
+			}
+		})
+	}
+}
+
+func TestRun_JSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-json", "transitions", "-n", "1", "-from", "2023-06-01", "Europe/Berlin"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, stderr: %s", code, stderr.String())
+	}
+	var got zoneTransitions
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Zone != "Europe/Berlin" || len(got.Transitions) != 1 || got.Transitions[0].UTC != "2023-10-29T01:00:00Z" {
+		t.Errorf("got %+v", got)
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit provides the rate limiters driven by the times.Clock,
+// which makes their behaviour deterministic in tests with an injected clock.
+//
+// The TokenBucket allows bursts of events and refills at a constant rate,
+// the SlidingWindowLog and SlidingWindowCounter limit the number of events in a rolling window,
+// and the KeyedLimiter keeps a separate limiter per key, i.e. a tenant, evicting the idle ones.
+package ratelimit
@@ -0,0 +1,104 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/blockysource/go-pkg/times"
+)
+
+// KeyedLimiter keeps a separate limiter for each key, i.e. a tenant or a client address.
+// The limiters not used for the idle timeout are evicted, so that the memory does not grow with the keys.
+// The idle timeout should not be shorter than the time the limiter needs to fully recover,
+// i.e. to refill the token bucket or to slide the window, as an evicted limiter is recreated in its initial state.
+type KeyedLimiter[K comparable, L Limiter] struct {
+	clock      times.Clock
+	idle       time.Duration
+	newLimiter func(K) L
+
+	mu        sync.Mutex
+	entries   map[K]*keyedEntry[L]
+	lastSweep time.Time
+}
+
+type keyedEntry[L Limiter] struct {
+	limiter  L
+	lastUsed time.Time
+}
+
+// NewKeyedLimiter creates a new KeyedLimiter, which creates the limiters of the keys with newLimiter.
+// The idle limiters are evicted lazily, when the limiter is accessed.
+func NewKeyedLimiter[K comparable, L Limiter](clock times.Clock, idle time.Duration, newLimiter func(K) L) *KeyedLimiter[K, L] {
+	return &KeyedLimiter[K, L]{
+		clock:      clock,
+		idle:       idle,
+		newLimiter: newLimiter,
+		entries:    make(map[K]*keyedEntry[L]),
+		lastSweep:  clock.Now(),
+	}
+}
+
+// Get returns the limiter of the key, creating it if needed.
+func (k *KeyedLimiter[K, L]) Get(key K) L {
+	now := k.clock.Now()
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if now.Sub(k.lastSweep) >= k.idle {
+		k.sweep(now)
+	}
+	e, ok := k.entries[key]
+	if !ok {
+		e = &keyedEntry[L]{limiter: k.newLimiter(key)}
+		k.entries[key] = e
+	}
+	e.lastUsed = now
+	return e.limiter
+}
+
+// Allow reports whether a single event of the key may happen now.
+func (k *KeyedLimiter[K, L]) Allow(key K) bool {
+	return k.Get(key).Allow()
+}
+
+// AllowN reports whether n events of the key may happen now.
+func (k *KeyedLimiter[K, L]) AllowN(key K, n int) bool {
+	return k.Get(key).AllowN(n)
+}
+
+// Len returns the number of the tracked keys.
+func (k *KeyedLimiter[K, L]) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return len(k.entries)
+}
+
+// Evict removes the limiters idle for the idle timeout.
+func (k *KeyedLimiter[K, L]) Evict() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.sweep(k.clock.Now())
+}
+
+func (k *KeyedLimiter[K, L]) sweep(now time.Time) {
+	for key, e := range k.entries {
+		if now.Sub(e.lastUsed) >= k.idle {
+			delete(k.entries, key)
+		}
+	}
+	k.lastSweep = now
+}
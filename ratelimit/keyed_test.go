@@ -0,0 +1,52 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestKeyedLimiter(t *testing.T) {
+	clock := newFakeClock()
+	k := NewKeyedLimiter(clock, time.Minute, func(string) *TokenBucket {
+		return NewTokenBucket(clock, Every(time.Second), 1)
+	})
+
+	if !k.Allow("a") || !k.Allow("b") {
+		t.Fatal("first events of the keys not allowed")
+	}
+	if k.Allow("a") {
+		t.Fatal("event over the limit of the key allowed")
+	}
+	if k.Len() != 2 {
+		t.Fatalf("Len: got %d", k.Len())
+	}
+
+	clock.Advance(30 * time.Second)
+	k.Get("a")
+	clock.Advance(30 * time.Second)
+	// The key "b" was idle for the timeout, and is evicted by the access.
+	k.Get("a")
+	if k.Len() != 1 {
+		t.Fatalf("Len after eviction: got %d", k.Len())
+	}
+
+	clock.Advance(time.Minute)
+	k.Evict()
+	if k.Len() != 0 {
+		t.Fatalf("Len after Evict: got %d", k.Len())
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/blockysource/go-pkg/times"
+)
+
+var (
+	// ErrExceedsBurst is returned when more events are requested at once than the limiter can ever allow.
+	ErrExceedsBurst = errors.New("ratelimit: requested events exceed the burst size")
+	// ErrWouldExceedDeadline is returned when waiting for the events would exceed the context deadline.
+	ErrWouldExceedDeadline = errors.New("ratelimit: wait would exceed the context deadline")
+)
+
+// Limiter is the interface implemented by all the limiters of this package.
+type Limiter interface {
+	// Allow reports whether a single event may happen now.
+	Allow() bool
+	// AllowN reports whether n events may happen now.
+	AllowN(n int) bool
+}
+
+// Sleeper is implemented by the clocks that can wait for a duration, i.e. the fake clocks in tests,
+// which advance their time instead of sleeping.
+// The limiters sleep with a timer, if their clock does not implement it.
+type Sleeper interface {
+	// Sleep waits for the duration d, or until the context is done.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// sleep waits for the duration d with the clock, if it is a Sleeper, or with a timer otherwise.
+func sleep(ctx context.Context, clock times.Clock, d time.Duration) error {
+	if s, ok := clock.(Sleeper); ok {
+		return s.Sleep(ctx, d)
+	}
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/blockysource/go-pkg/times"
+)
+
+var (
+	_ Limiter = (*SlidingWindowLog)(nil)
+	_ Limiter = (*SlidingWindowCounter)(nil)
+)
+
+// SlidingWindowLog allows up to limit events in any window of the given length.
+// It logs the time of each allowed event, thus it is exact, but uses memory proportional to the limit.
+type SlidingWindowLog struct {
+	clock  times.Clock
+	limit  int
+	window time.Duration
+
+	mu     sync.Mutex
+	events []time.Time // The ring buffer of the allowed events.
+	head   int         // The index of the oldest event.
+	size   int         // The number of logged events.
+}
+
+// NewSlidingWindowLog creates a new SlidingWindowLog, driven by the clock.
+// The negative limit is treated as zero, which allows no events.
+func NewSlidingWindowLog(clock times.Clock, limit int, window time.Duration) *SlidingWindowLog {
+	if limit < 0 {
+		limit = 0
+	}
+	return &SlidingWindowLog{
+		clock:  clock,
+		limit:  limit,
+		window: window,
+		events: make([]time.Time, limit),
+	}
+}
+
+// Allow reports whether a single event may happen now.
+func (l *SlidingWindowLog) Allow() bool {
+	return l.AllowN(1)
+}
+
+// AllowN reports whether n events may happen now, and logs them if so.
+func (l *SlidingWindowLog) AllowN(n int) bool {
+	now := l.clock.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire(now)
+	if l.size+n > l.limit {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		l.events[(l.head+l.size)%l.limit] = now
+		l.size++
+	}
+	return true
+}
+
+// Count returns the number of events in the current window.
+func (l *SlidingWindowLog) Count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire(l.clock.Now())
+	return l.size
+}
+
+// RetryAfter returns the time until n events may happen, or a negative duration if they never can.
+func (l *SlidingWindowLog) RetryAfter(n int) time.Duration {
+	if n > l.limit {
+		return -1
+	}
+	now := l.clock.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire(now)
+	excess := l.size + n - l.limit
+	if excess <= 0 {
+		return 0
+	}
+	// The excess oldest events have to leave the window.
+	oldest := l.events[(l.head+excess-1)%l.limit]
+	return oldest.Add(l.window).Sub(now)
+}
+
+// expire removes the events that left the window at now.
+func (l *SlidingWindowLog) expire(now time.Time) {
+	start := now.Add(-l.window)
+	for l.size > 0 && !l.events[l.head].After(start) {
+		l.head = (l.head + 1) % l.limit
+		l.size--
+	}
+}
+
+// SlidingWindowCounter approximates the number of events in the sliding window,
+// by weighting the count of the previous fixed window with its part still covered by the sliding window.
+// It uses constant memory, at the cost of accuracy when the events are not evenly distributed.
+type SlidingWindowCounter struct {
+	clock  times.Clock
+	limit  int
+	window time.Duration
+
+	mu    sync.Mutex
+	start time.Time // The start of the current fixed window.
+	curr  int       // The count of the current fixed window.
+	prev  int       // The count of the previous fixed window.
+}
+
+// NewSlidingWindowCounter creates a new SlidingWindowCounter, driven by the clock.
+// The fixed windows are aligned to the multiples of the window, as with time.Time.Truncate.
+func NewSlidingWindowCounter(clock times.Clock, limit int, window time.Duration) *SlidingWindowCounter {
+	return &SlidingWindowCounter{clock: clock, limit: limit, window: window}
+}
+
+// Allow reports whether a single event may happen now.
+func (c *SlidingWindowCounter) Allow() bool {
+	return c.AllowN(1)
+}
+
+// AllowN reports whether n events may happen now, and counts them if so.
+func (c *SlidingWindowCounter) AllowN(n int) bool {
+	now := c.clock.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.estimate(now)+float64(n) > float64(c.limit) {
+		return false
+	}
+	c.curr += n
+	return true
+}
+
+// Count returns the estimated number of events in the current sliding window.
+func (c *SlidingWindowCounter) Count() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.estimate(c.clock.Now())
+}
+
+// estimate rotates the fixed windows up to now, and returns the estimated count of the sliding window.
+func (c *SlidingWindowCounter) estimate(now time.Time) float64 {
+	start := now.Truncate(c.window)
+	switch elapsed := start.Sub(c.start); {
+	case elapsed == c.window:
+		c.prev, c.curr = c.curr, 0
+		c.start = start
+	case elapsed > c.window || c.start.IsZero():
+		c.prev, c.curr = 0, 0
+		c.start = start
+	}
+	weight := 1 - float64(now.Sub(c.start))/float64(c.window)
+	return float64(c.prev)*weight + float64(c.curr)
+}
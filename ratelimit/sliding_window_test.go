@@ -0,0 +1,93 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSlidingWindowLog(t *testing.T) {
+	clock := newFakeClock()
+	l := NewSlidingWindowLog(clock, 3, time.Minute)
+
+	if !l.AllowN(2) {
+		t.Fatal("first events not allowed")
+	}
+	clock.Advance(30 * time.Second)
+	if !l.Allow() {
+		t.Fatal("third event not allowed")
+	}
+	if l.Allow() {
+		t.Fatal("event over the limit allowed")
+	}
+	if d := l.RetryAfter(1); d != 30*time.Second {
+		t.Fatalf("RetryAfter(1): got %v", d)
+	}
+	if d := l.RetryAfter(3); d != time.Minute {
+		t.Fatalf("RetryAfter(3): got %v", d)
+	}
+	if d := l.RetryAfter(4); d >= 0 {
+		t.Fatalf("RetryAfter(4): got %v", d)
+	}
+
+	// The first two events leave the window.
+	clock.Advance(30 * time.Second)
+	if l.Count() != 1 {
+		t.Fatalf("Count: got %d", l.Count())
+	}
+	if !l.AllowN(2) {
+		t.Fatal("events after the window slid not allowed")
+	}
+	if l.Allow() {
+		t.Fatal("event over the limit allowed")
+	}
+
+	// The negative limit allows no events.
+	if l = NewSlidingWindowLog(clock, -1, time.Minute); l.Allow() || l.RetryAfter(1) >= 0 {
+		t.Fatal("event over the negative limit allowed")
+	}
+}
+
+func TestSlidingWindowCounter(t *testing.T) {
+	clock := newFakeClock()
+	clock.now = clock.now.Truncate(time.Minute)
+	c := NewSlidingWindowCounter(clock, 10, time.Minute)
+
+	if !c.AllowN(10) {
+		t.Fatal("first events not allowed")
+	}
+	if c.Allow() {
+		t.Fatal("event over the limit allowed")
+	}
+
+	// A quarter into the next window, the previous one still weighs 3/4.
+	clock.Advance(75 * time.Second)
+	if got := c.Count(); got != 7.5 {
+		t.Fatalf("Count: got %v, want 7.5", got)
+	}
+	if !c.AllowN(2) {
+		t.Fatal("events under the estimate not allowed")
+	}
+	if c.Allow() {
+		t.Fatal("event over the estimate allowed")
+	}
+
+	// The windows older than the previous one are forgotten.
+	clock.Advance(2 * time.Minute)
+	if got := c.Count(); got != 0 {
+		t.Fatalf("Count after idle: got %v", got)
+	}
+}
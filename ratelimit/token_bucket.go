@@ -0,0 +1,248 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/blockysource/go-pkg/times"
+)
+
+// Limit is the rate of events per second.
+type Limit float64
+
+// Inf is the infinite rate limit, which allows all the events.
+const Inf = Limit(math.MaxFloat64)
+
+// Every converts the minimum interval between events to a Limit.
+func Every(interval time.Duration) Limit {
+	if interval <= 0 {
+		return Inf
+	}
+	return 1 / Limit(interval.Seconds())
+}
+
+// durationFor returns the time needed to accumulate the tokens at the limit.
+func (l Limit) durationFor(tokens float64) time.Duration {
+	if l <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	seconds := tokens / float64(l)
+	if seconds >= float64(math.MaxInt64)/float64(time.Second) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+var _ Limiter = (*TokenBucket)(nil)
+
+// TokenBucket is a token bucket rate limiter.
+// The bucket holds up to burst tokens, and is refilled at the limit of tokens per second.
+// Each event takes a single token.
+type TokenBucket struct {
+	clock times.Clock
+	limit Limit
+	burst int
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+	// lastEvent is the latest time to act of the reservations.
+	lastEvent time.Time
+}
+
+// NewTokenBucket creates a new full TokenBucket, driven by the clock.
+func NewTokenBucket(clock times.Clock, limit Limit, burst int) *TokenBucket {
+	return &TokenBucket{
+		clock:  clock,
+		limit:  limit,
+		burst:  burst,
+		tokens: float64(burst),
+		last:   clock.Now(),
+	}
+}
+
+// Limit returns the rate of the bucket refill.
+func (b *TokenBucket) Limit() Limit {
+	return b.limit
+}
+
+// Burst returns the size of the bucket.
+func (b *TokenBucket) Burst() int {
+	return b.burst
+}
+
+// Tokens returns the number of tokens available now. It is negative if the tokens are reserved ahead.
+func (b *TokenBucket) Tokens() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.advance(b.clock.Now())
+}
+
+// Allow reports whether a single event may happen now.
+func (b *TokenBucket) Allow() bool {
+	return b.AllowN(1)
+}
+
+// AllowN reports whether n events may happen now, and takes their tokens if so.
+func (b *TokenBucket) AllowN(n int) bool {
+	return b.reserveN(b.clock.Now(), n, 0).ok
+}
+
+// Reserve reserves a single event. See ReserveN.
+func (b *TokenBucket) Reserve() *Reservation {
+	return b.ReserveN(1)
+}
+
+// ReserveN reserves n events, which may happen after the Reservation.Delay.
+// The reservation is not OK if n exceeds the burst size.
+// The caller that decides not to act on the reservation should Cancel it.
+func (b *TokenBucket) ReserveN(n int) *Reservation {
+	return b.reserveN(b.clock.Now(), n, time.Duration(math.MaxInt64))
+}
+
+// Wait blocks until a single event may happen. See WaitN.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	return b.WaitN(ctx, 1)
+}
+
+// WaitN blocks until n events may happen.
+// It returns ErrExceedsBurst if n exceeds the burst size, ErrWouldExceedDeadline if the wait
+// would not end before the context deadline, or the context error if it is done while waiting.
+func (b *TokenBucket) WaitN(ctx context.Context, n int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if n > b.burst && b.limit != Inf {
+		return ErrExceedsBurst
+	}
+
+	now := b.clock.Now()
+	maxWait := time.Duration(math.MaxInt64)
+	if deadline, ok := ctx.Deadline(); ok {
+		// The context deadline is measured by the real time, not by the clock.
+		maxWait = time.Until(deadline)
+	}
+	r := b.reserveN(now, n, maxWait)
+	if !r.ok {
+		return ErrWouldExceedDeadline
+	}
+	if err := sleep(ctx, b.clock, r.DelayFrom(now)); err != nil {
+		r.Cancel()
+		return err
+	}
+	return nil
+}
+
+// advance refills the bucket up to now, and returns the number of tokens.
+func (b *TokenBucket) advance(now time.Time) float64 {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		if b.limit == Inf {
+			b.tokens = float64(b.burst)
+		} else {
+			b.tokens = math.Min(float64(b.burst), b.tokens+elapsed.Seconds()*float64(b.limit))
+		}
+		b.last = now
+	}
+	return b.tokens
+}
+
+func (b *TokenBucket) reserveN(now time.Time, n int, maxWait time.Duration) *Reservation {
+	if b.limit == Inf {
+		return &Reservation{ok: true, timeToAct: now}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	tokens := b.advance(now) - float64(n)
+	var wait time.Duration
+	if tokens < 0 {
+		wait = b.limit.durationFor(-tokens)
+	}
+	if n > b.burst || wait > maxWait {
+		return &Reservation{}
+	}
+	b.tokens = tokens
+	timeToAct := now.Add(wait)
+	if timeToAct.After(b.lastEvent) {
+		b.lastEvent = timeToAct
+	}
+	return &Reservation{ok: true, bucket: b, tokens: n, timeToAct: timeToAct}
+}
+
+// Reservation holds the events reserved in the TokenBucket, which may happen after a delay.
+type Reservation struct {
+	ok        bool
+	bucket    *TokenBucket
+	tokens    int
+	timeToAct time.Time
+}
+
+// OK reports whether the events were reserved. The reservation fails if their number exceeds the burst size.
+func (r *Reservation) OK() bool {
+	return r.ok
+}
+
+// Delay returns the time to wait before the events may happen, according to the bucket clock.
+func (r *Reservation) Delay() time.Duration {
+	if r.bucket == nil {
+		return r.DelayFrom(r.timeToAct)
+	}
+	return r.DelayFrom(r.bucket.clock.Now())
+}
+
+// DelayFrom returns the time to wait from now, before the events may happen.
+// It returns the maximum duration if the reservation is not OK.
+func (r *Reservation) DelayFrom(now time.Time) time.Duration {
+	if !r.ok {
+		return time.Duration(math.MaxInt64)
+	}
+	if d := r.timeToAct.Sub(now); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// Cancel returns the reserved tokens to the bucket, if the events were not yet due to happen.
+// The tokens of the later reservations, which waited for the canceled ones, are not returned,
+// as these reservations keep their times to act.
+func (r *Reservation) Cancel() {
+	if !r.ok || r.bucket == nil {
+		return
+	}
+	b := r.bucket
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock.Now()
+	if !now.Before(r.timeToAct) {
+		return
+	}
+	// The later reservations have taken the tokens refilled until their times to act.
+	restore := float64(r.tokens) - b.lastEvent.Sub(r.timeToAct).Seconds()*float64(b.limit)
+	r.ok, r.bucket = false, nil
+	if restore <= 0 {
+		return
+	}
+	b.advance(now)
+	b.tokens = math.Min(float64(b.burst), b.tokens+restore)
+	if r.timeToAct.Equal(b.lastEvent) {
+		b.lastEvent = r.timeToAct.Add(-b.limit.durationFor(float64(r.tokens)))
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket_Allow(t *testing.T) {
+	clock := newFakeClock()
+	b := NewTokenBucket(clock, 2, 3)
+
+	for i := 0; i < 3; i++ {
+		if !b.Allow() {
+			t.Fatalf("event %d of the burst not allowed", i)
+		}
+	}
+	if b.Allow() {
+		t.Fatal("event over the burst allowed")
+	}
+
+	// 2 tokens per second.
+	clock.Advance(500 * time.Millisecond)
+	if !b.Allow() {
+		t.Fatal("refilled token not allowed")
+	}
+	if b.Allow() {
+		t.Fatal("event over the refill allowed")
+	}
+
+	// The bucket does not grow over the burst.
+	clock.Advance(time.Hour)
+	if b.AllowN(4) {
+		t.Fatal("events over the burst allowed")
+	}
+	if !b.AllowN(3) {
+		t.Fatal("full burst not allowed")
+	}
+}
+
+func TestTokenBucket_Reserve(t *testing.T) {
+	clock := newFakeClock()
+	b := NewTokenBucket(clock, Every(100*time.Millisecond), 1)
+
+	r1 := b.Reserve()
+	if !r1.OK() || r1.Delay() != 0 {
+		t.Fatalf("first reservation: ok %v, delay %v", r1.OK(), r1.Delay())
+	}
+	r2 := b.Reserve()
+	if !r2.OK() || r2.Delay() != 100*time.Millisecond {
+		t.Fatalf("second reservation: ok %v, delay %v", r2.OK(), r2.Delay())
+	}
+	r3 := b.Reserve()
+	if r3.Delay() != 200*time.Millisecond {
+		t.Fatalf("third reservation: delay %v", r3.Delay())
+	}
+
+	// Canceling returns the tokens.
+	r3.Cancel()
+	r4 := b.Reserve()
+	if r4.Delay() != 200*time.Millisecond {
+		t.Fatalf("reservation after cancel: delay %v", r4.Delay())
+	}
+
+	// The token of the earlier reservation is taken by the later ones, canceling it returns nothing.
+	r2.Cancel()
+	if r5 := b.Reserve(); r5.Delay() != 300*time.Millisecond {
+		t.Fatalf("reservation after canceling the earlier one: delay %v", r5.Delay())
+	}
+
+	if r := b.ReserveN(2); r.OK() {
+		t.Fatal("reservation over the burst is OK")
+	}
+}
+
+func TestTokenBucket_Wait(t *testing.T) {
+	clock := newFakeClock()
+	b := NewTokenBucket(clock, 10, 1)
+	start := clock.Now()
+
+	for i := 0; i < 3; i++ {
+		if err := b.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait: %v", err)
+		}
+	}
+	if elapsed := clock.Since(start); elapsed != 200*time.Millisecond {
+		t.Fatalf("waited %v, want 200ms", elapsed)
+	}
+
+	if err := b.WaitN(context.Background(), 2); !errors.Is(err, ErrExceedsBurst) {
+		t.Fatalf("WaitN over burst: got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := b.Wait(ctx); !errors.Is(err, ErrWouldExceedDeadline) {
+		t.Fatalf("Wait over deadline: got %v", err)
+	}
+	// The failed wait did not take the token.
+	if r := b.Reserve(); r.Delay() != 100*time.Millisecond {
+		t.Fatalf("reservation after failed wait: delay %v", r.Delay())
+	}
+}
+
+func TestTokenBucket_Inf(t *testing.T) {
+	b := NewTokenBucket(newFakeClock(), Inf, 0)
+	if !b.AllowN(1000) {
+		t.Fatal("infinite limit did not allow the events")
+	}
+}
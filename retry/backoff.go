@@ -0,0 +1,269 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Backoff controls the pauses between the attempts of a retry loop.
+// The retry loop calls Reset before the first attempt, and Pause before each retry.
+// A Backoff holds the state of a single retry loop, it must not be shared by concurrent calls.
+type Backoff interface {
+	// Pause returns the duration of the next pause.
+	Pause() time.Duration
+	// Reset restores the initial state of the backoff.
+	Reset()
+}
+
+// Default backoff parameters, matching the gax.Backoff defaults.
+const (
+	DefaultInitialBackoff    = time.Second
+	DefaultMaxBackoff        = 30 * time.Second
+	DefaultBackoffMultiplier = 2
+)
+
+// DefaultBackoff returns the backoff used when none is set,
+// the full jitter backoff with the default parameters.
+func DefaultBackoff() Backoff {
+	return NewFullJitterBackoff(DefaultInitialBackoff, DefaultMaxBackoff, DefaultBackoffMultiplier)
+}
+
+// ConstantBackoff pauses for the same delay before every retry.
+type ConstantBackoff struct {
+	delay time.Duration
+}
+
+// NewConstantBackoff creates a new ConstantBackoff, pausing for the delay.
+func NewConstantBackoff(delay time.Duration) *ConstantBackoff {
+	return &ConstantBackoff{delay: nonNegative(delay)}
+}
+
+// Pause returns the constant delay.
+func (b *ConstantBackoff) Pause() time.Duration {
+	return b.delay
+}
+
+// Reset does nothing, the ConstantBackoff has no state.
+func (b *ConstantBackoff) Reset() {}
+
+// LinearBackoff pauses for the initial delay, increased by the step before every next retry, up to the maximum.
+type LinearBackoff struct {
+	initial, step, max time.Duration
+	cur                time.Duration
+}
+
+// NewLinearBackoff creates a new LinearBackoff. A non-positive max means no maximum.
+func NewLinearBackoff(initial, step, max time.Duration) *LinearBackoff {
+	b := &LinearBackoff{initial: nonNegative(initial), step: nonNegative(step), max: max}
+	b.Reset()
+	return b
+}
+
+// Pause returns the current delay and increases it by the step.
+func (b *LinearBackoff) Pause() time.Duration {
+	d := b.cur
+	b.cur = capDelay(addDelay(b.cur, b.step), b.max)
+	return d
+}
+
+// Reset restores the initial delay.
+func (b *LinearBackoff) Reset() {
+	b.cur = capDelay(b.initial, b.max)
+}
+
+// ExponentialBackoff pauses for the initial delay, multiplied before every next retry, up to the maximum.
+// The pauses are not randomized, see NewFullJitterBackoff and NewEqualJitterBackoff for the randomized ones.
+type ExponentialBackoff struct {
+	initial, max time.Duration
+	multiplier   float64
+	cur          time.Duration
+}
+
+// NewExponentialBackoff creates a new ExponentialBackoff. A non-positive max means no maximum,
+// and a multiplier less than 1 is treated as 1.
+func NewExponentialBackoff(initial, max time.Duration, multiplier float64) *ExponentialBackoff {
+	b := &ExponentialBackoff{initial: nonNegative(initial), max: max, multiplier: math.Max(multiplier, 1)}
+	b.Reset()
+	return b
+}
+
+// Pause returns the current delay and multiplies it.
+func (b *ExponentialBackoff) Pause() time.Duration {
+	d := b.cur
+	b.cur = capDelay(mulDelay(b.cur, b.multiplier), b.max)
+	return d
+}
+
+// Reset restores the initial delay.
+func (b *ExponentialBackoff) Reset() {
+	b.cur = capDelay(b.initial, b.max)
+}
+
+// JitterKind is the randomization strategy of the JitterBackoff.
+type JitterKind int
+
+const (
+	// FullJitter pauses for a random delay in [0, d], where d grows exponentially.
+	FullJitter JitterKind = iota
+	// EqualJitter pauses for a random delay in [d/2, d], where d grows exponentially.
+	EqualJitter
+	// DecorrelatedJitter pauses for a random delay in [initial, 3*previous pause], up to the maximum.
+	DecorrelatedJitter
+)
+
+// JitterBackoff pauses for the randomized delays, which spread the retries of the concurrent clients.
+// By default, it uses the global random source; WithSeed makes the pauses deterministic, i.e. in tests.
+type JitterBackoff struct {
+	kind         JitterKind
+	initial, max time.Duration
+	multiplier   float64
+	cur          time.Duration
+
+	seed   int64
+	seeded bool
+	rnd    *rand.Rand
+}
+
+// NewFullJitterBackoff creates a new JitterBackoff, pausing for a random delay up to the exponential one.
+// A non-positive max means no maximum, and a multiplier less than 1 is treated as 1.
+func NewFullJitterBackoff(initial, max time.Duration, multiplier float64) *JitterBackoff {
+	return newJitterBackoff(FullJitter, initial, max, multiplier)
+}
+
+// NewEqualJitterBackoff creates a new JitterBackoff, pausing for at least a half of the exponential delay,
+// and a random delay up to the other half. A non-positive max means no maximum,
+// and a multiplier less than 1 is treated as 1.
+func NewEqualJitterBackoff(initial, max time.Duration, multiplier float64) *JitterBackoff {
+	return newJitterBackoff(EqualJitter, initial, max, multiplier)
+}
+
+// NewDecorrelatedJitterBackoff creates a new JitterBackoff, pausing for a random delay
+// between the initial one and three times the previous pause. A non-positive max means no maximum.
+func NewDecorrelatedJitterBackoff(initial, max time.Duration) *JitterBackoff {
+	return newJitterBackoff(DecorrelatedJitter, initial, max, 3)
+}
+
+func newJitterBackoff(kind JitterKind, initial, max time.Duration, multiplier float64) *JitterBackoff {
+	b := &JitterBackoff{kind: kind, initial: nonNegative(initial), max: max, multiplier: math.Max(multiplier, 1)}
+	b.Reset()
+	return b
+}
+
+// WithSeed makes the pauses deterministic, by using its own random source seeded with seed.
+// Reset restarts the random sequence, so that every retry loop pauses the same.
+func (b *JitterBackoff) WithSeed(seed int64) *JitterBackoff {
+	b.seed, b.seeded = seed, true
+	b.Reset()
+	return b
+}
+
+// Kind returns the randomization strategy of the backoff.
+func (b *JitterBackoff) Kind() JitterKind {
+	return b.kind
+}
+
+// Pause returns the next random delay.
+func (b *JitterBackoff) Pause() time.Duration {
+	switch b.kind {
+	case EqualJitter:
+		d := b.cur
+		b.cur = capDelay(mulDelay(b.cur, b.multiplier), b.max)
+		half := d / 2
+		return d - half + b.random(half)
+	case DecorrelatedJitter:
+		// The next pause is in [initial, 3*cur], where cur is the previous pause.
+		upper := capDelay(mulDelay(b.cur, b.multiplier), b.max)
+		if upper < b.initial {
+			upper = b.initial
+		}
+		d := b.initial + b.random(upper-b.initial)
+		b.cur = d
+		return d
+	default:
+		d := b.cur
+		b.cur = capDelay(mulDelay(b.cur, b.multiplier), b.max)
+		return b.random(d)
+	}
+}
+
+// Reset restores the initial delay, and restarts the random sequence, if seeded.
+func (b *JitterBackoff) Reset() {
+	b.cur = capDelay(b.initial, b.max)
+	if b.seeded {
+		b.rnd = rand.New(rand.NewSource(b.seed))
+	}
+}
+
+// random returns a random duration in [0, d].
+func (b *JitterBackoff) random(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	if d == math.MaxInt64 {
+		d--
+	}
+	if b.rnd != nil {
+		return time.Duration(b.rnd.Int63n(int64(d) + 1))
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// capDelay limits d to max, if max is positive.
+func capDelay(d, max time.Duration) time.Duration {
+	if max > 0 && d > max {
+		return max
+	}
+	return d
+}
+
+// addDelay returns a+b, saturated at the maximum duration.
+func addDelay(a, b time.Duration) time.Duration {
+	if a > math.MaxInt64-b {
+		return math.MaxInt64
+	}
+	return a + b
+}
+
+// mulDelay returns d*m, saturated at the maximum duration.
+func mulDelay(d time.Duration, m float64) time.Duration {
+	f := float64(d) * m
+	if f >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(f)
+}
+
+// sleep pauses for d, or until the context is done, in which case it returns the context error.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	select {
+	case <-ctx.Done():
+		t.Stop()
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func pauses(bo Backoff, n int) []time.Duration {
+	ds := make([]time.Duration, n)
+	for i := range ds {
+		ds[i] = bo.Pause()
+	}
+	return ds
+}
+
+func TestDeterministicBackoff(t *testing.T) {
+	for _, test := range []struct {
+		desc string
+		bo   Backoff
+		want []time.Duration
+	}{
+		{
+			desc: "constant",
+			bo:   NewConstantBackoff(time.Second),
+			want: []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{
+			desc: "linear",
+			bo:   NewLinearBackoff(time.Second, 2*time.Second, 6*time.Second),
+			want: []time.Duration{time.Second, 3 * time.Second, 5 * time.Second, 6 * time.Second},
+		},
+		{
+			desc: "exponential",
+			bo:   NewExponentialBackoff(time.Second, 10*time.Second, 3),
+			want: []time.Duration{time.Second, 3 * time.Second, 9 * time.Second, 10 * time.Second},
+		},
+		{
+			desc: "exponential without maximum",
+			bo:   NewExponentialBackoff(time.Second, 0, 2),
+			want: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second},
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			if got := pauses(test.bo, len(test.want)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+			test.bo.Reset()
+			if got := pauses(test.bo, len(test.want)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("after reset: got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestJitterBackoff(t *testing.T) {
+	const n = 8
+	for _, test := range []struct {
+		desc string
+		bo   *JitterBackoff
+		// bounds returns the range of the i-th pause, given the previous one.
+		bounds func(i int, prev time.Duration) (time.Duration, time.Duration)
+	}{
+		{
+			desc: "full",
+			bo:   NewFullJitterBackoff(time.Second, 16*time.Second, 2),
+			bounds: func(i int, _ time.Duration) (time.Duration, time.Duration) {
+				return 0, capDelay(time.Second<<i, 16*time.Second)
+			},
+		},
+		{
+			desc: "equal",
+			bo:   NewEqualJitterBackoff(time.Second, 16*time.Second, 2),
+			bounds: func(i int, _ time.Duration) (time.Duration, time.Duration) {
+				d := capDelay(time.Second<<i, 16*time.Second)
+				return d / 2, d
+			},
+		},
+		{
+			desc: "decorrelated",
+			bo:   NewDecorrelatedJitterBackoff(time.Second, 16*time.Second),
+			bounds: func(i int, prev time.Duration) (time.Duration, time.Duration) {
+				if i == 0 {
+					prev = time.Second
+				}
+				return time.Second, capDelay(3*prev, 16*time.Second)
+			},
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			bo := test.bo.WithSeed(42)
+			got := pauses(bo, n)
+			var prev time.Duration
+			for i, d := range got {
+				lo, hi := test.bounds(i, prev)
+				if d < lo || d > hi {
+					t.Errorf("pause %d: got %v, want in [%v, %v]", i, d, lo, hi)
+				}
+				prev = d
+			}
+			bo.Reset()
+			if again := pauses(bo, n); !reflect.DeepEqual(got, again) {
+				t.Errorf("seeded pauses differ after reset: %v, %v", got, again)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+)
+
+// Classifier reports whether the error should be retried, it can be passed to WithRetryable and Call.
+type Classifier func(error) bool
+
+// Any returns the Classifier retrying the errors retried by any of the classifiers.
+// Without the classifiers, no error is retried.
+func Any(classifiers ...Classifier) Classifier {
+	return func(err error) bool {
+		for _, c := range classifiers {
+			if c(err) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// All returns the Classifier retrying the errors retried by all the classifiers.
+// Without the classifiers, every error is retried.
+func All(classifiers ...Classifier) Classifier {
+	return func(err error) bool {
+		for _, c := range classifiers {
+			if !c(err) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Not returns the Classifier retrying the errors not retried by the classifier.
+func Not(c Classifier) Classifier {
+	return func(err error) bool {
+		return !c(err)
+	}
+}
+
+// NetworkErrors returns the Classifier retrying the transient network errors: the timeouts,
+// the temporary DNS failures, the connections reset, refused or aborted, the broken pipes,
+// and the EOFs of the HTTP requests or the network operations, wrapped in a *url.Error or a *net.OpError,
+// which are returned, i.e. when the server closes an idle connection. The other EOFs,
+// i.e. of decoding a truncated body or reading a file, are not retried.
+// The context errors are not retried, even though context.DeadlineExceeded is a timeout.
+func NetworkErrors() Classifier {
+	return isNetworkError
+}
+
+func isNetworkError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	for _, target := range []error{syscall.ECONNRESET, syscall.ECONNREFUSED, syscall.ECONNABORTED, syscall.EPIPE} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && isEOF(urlErr.Err) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && isEOF(opErr.Err) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func isEOF(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// HTTPStatusErrors returns the Classifier retrying the HTTPStatusErrors with the retryable status codes,
+// see RetryableHTTPStatus. The idempotent reports whether the failed requests are idempotent.
+func HTTPStatusErrors(idempotent bool) Classifier {
+	return func(err error) bool {
+		var statusErr *HTTPStatusError
+		return errors.As(err, &statusErr) && RetryableHTTPStatus(statusErr.StatusCode, idempotent)
+	}
+}
+
+// RetryableHTTPStatus reports whether the request which failed with the status code should be retried.
+// The 408 Request Timeout, 425 Too Early and 429 Too Many Requests responses mean that the server
+// did not process the request, thus they are retried regardless of the idempotency.
+// The 500 Internal Server Error, 502 Bad Gateway, 503 Service Unavailable and 504 Gateway Timeout
+// responses are retried only if the request is idempotent, as it might have been processed.
+func RetryableHTTPStatus(code int, idempotent bool) bool {
+	switch code {
+	case http.StatusRequestTimeout, http.StatusTooEarly, http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// IdempotentMethod reports whether the HTTP method is idempotent, as defined by RFC 9110.
+// The empty method is GET, as in http.Request.
+func IdempotentMethod(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// The SQLSTATE codes of the transient SQL errors.
+const (
+	SQLStateSerializationFailure = "40001"
+	SQLStateDeadlockDetected     = "40P01"
+)
+
+// SQLStates returns the Classifier retrying the SQL errors with the SQLSTATE codes,
+// reported by the SQLState() string method of the error, which is implemented i.e. by the pgx and pq drivers.
+// A two characters state matches the whole class of the codes, i.e. "08" the connection exceptions.
+// Without the states, the serialization failures and the deadlocks are retried.
+func SQLStates(states ...string) Classifier {
+	if len(states) == 0 {
+		states = []string{SQLStateSerializationFailure, SQLStateDeadlockDetected}
+	}
+	return func(err error) bool {
+		var sqlErr interface{ SQLState() string }
+		if !errors.As(err, &sqlErr) {
+			return false
+		}
+		code := sqlErr.SQLState()
+		for _, state := range states {
+			if code == state || (len(state) == 2 && len(code) == 5 && code[:2] == state) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type sqlError string
+
+func (e sqlError) Error() string    { return "sql error " + string(e) }
+func (e sqlError) SQLState() string { return string(e) }
+
+func TestNetworkErrors(t *testing.T) {
+	isRetryable := NetworkErrors()
+	opErr := func(err error) error {
+		return &url.Error{Op: "Get", URL: "http://example.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", err)}}
+	}
+	for _, test := range []struct {
+		err  error
+		want bool
+	}{
+		{opErr(syscall.ECONNREFUSED), true},
+		{opErr(syscall.ECONNRESET), true},
+		{opErr(syscall.EACCES), false},
+		{&url.Error{Op: "Get", URL: "http://example.com", Err: io.EOF}, true},
+		{&net.OpError{Op: "read", Net: "tcp", Err: io.ErrUnexpectedEOF}, true},
+		{fmt.Errorf("call: %w", &url.Error{Op: "Post", URL: "http://example.com", Err: io.ErrUnexpectedEOF}), true},
+		{io.ErrUnexpectedEOF, false},
+		{fmt.Errorf("decode body: %w", io.EOF), false},
+		{&net.DNSError{Err: "timeout", IsTimeout: true}, true},
+		{&net.DNSError{Err: "no such host", IsNotFound: true}, false},
+		{os.ErrDeadlineExceeded, true},
+		{context.DeadlineExceeded, false},
+		{fmt.Errorf("request: %w", context.Canceled), false},
+		{errRetry, false},
+		{nil, false},
+	} {
+		if got := isRetryable(test.err); got != test.want {
+			t.Errorf("NetworkErrors(%v): got %v, want %v", test.err, got, test.want)
+		}
+	}
+}
+
+func TestHTTPStatusErrors(t *testing.T) {
+	for _, test := range []struct {
+		code                      int
+		idempotent, nonIdempotent bool
+	}{
+		{408, true, true},
+		{425, true, true},
+		{429, true, true},
+		{500, true, false},
+		{503, true, false},
+		{501, false, false},
+		{404, false, false},
+	} {
+		err := fmt.Errorf("call: %w", &HTTPStatusError{StatusCode: test.code})
+		if got := HTTPStatusErrors(true)(err); got != test.idempotent {
+			t.Errorf("%d idempotent: got %v, want %v", test.code, got, test.idempotent)
+		}
+		if got := HTTPStatusErrors(false)(err); got != test.nonIdempotent {
+			t.Errorf("%d non-idempotent: got %v, want %v", test.code, got, test.nonIdempotent)
+		}
+	}
+	if HTTPStatusErrors(true)(errRetry) {
+		t.Error("non-HTTP error is retried")
+	}
+}
+
+func TestSQLStates(t *testing.T) {
+	for _, test := range []struct {
+		isRetryable Classifier
+		err         error
+		want        bool
+	}{
+		{SQLStates(), sqlError(SQLStateSerializationFailure), true},
+		{SQLStates(), fmt.Errorf("tx: %w", sqlError(SQLStateDeadlockDetected)), true},
+		{SQLStates(), sqlError("23505"), false},
+		{SQLStates("08"), sqlError("08006"), true},
+		{SQLStates("08"), sqlError("40001"), false},
+		{SQLStates(), errRetry, false},
+	} {
+		if got := test.isRetryable(test.err); got != test.want {
+			t.Errorf("%v: got %v, want %v", test.err, got, test.want)
+		}
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	isRetry := Classifier(retryable)
+	isSQL := SQLStates()
+	for _, test := range []struct {
+		desc        string
+		isRetryable Classifier
+		err         error
+		want        bool
+	}{
+		{"any first", Any(isRetry, isSQL), errRetry, true},
+		{"any second", Any(isRetry, isSQL), sqlError(SQLStateSerializationFailure), true},
+		{"any none", Any(isRetry, isSQL), errNoRetry, false},
+		{"any empty", Any(), errRetry, false},
+		{"all", All(NetworkErrors(), Not(Classifier(func(err error) bool { return errors.Is(err, io.EOF) }))), &net.OpError{Err: io.ErrUnexpectedEOF}, true},
+		{"all excluded", All(NetworkErrors(), Not(Classifier(func(err error) bool { return errors.Is(err, io.EOF) }))), &net.OpError{Err: io.EOF}, false},
+		{"all empty", All(), errNoRetry, true},
+		{"not", Not(isRetry), errNoRetry, true},
+	} {
+		if got := test.isRetryable(test.err); got != test.want {
+			t.Errorf("%s: got %v, want %v", test.desc, got, test.want)
+		}
+	}
+
+	// The classifiers are accepted by Call.
+	gotCount := 0
+	err := call(context.Background(), nil, Any(isRetry, isSQL), func() error {
+		gotCount++
+		if gotCount < 2 {
+			return sqlError(SQLStateDeadlockDetected)
+		}
+		return errNoRetry
+	}, func(context.Context, time.Duration) error { return nil })
+	if err != errNoRetry || gotCount != 2 {
+		t.Errorf("got %v after %d calls, want errNoRetry after 2", err, gotCount)
+	}
+}
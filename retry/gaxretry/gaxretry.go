@@ -0,0 +1,54 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gaxretry adapts the gax.Backoff to the retry package.
+// It is separated, so that the retry package does not depend on gax.
+package gaxretry
+
+import (
+	"context"
+	"time"
+
+	"github.com/googleapis/gax-go/v2"
+
+	"github.com/blockysource/go-pkg/retry"
+)
+
+// Backoff returns the retry.Backoff pausing as the bo.
+// Reset restores the copy of the bo, thus the zero gax.Backoff uses the gax defaults.
+func Backoff(bo gax.Backoff) retry.Backoff {
+	return &backoff{initial: bo, cur: bo}
+}
+
+type backoff struct {
+	initial, cur gax.Backoff
+}
+
+func (b *backoff) Pause() time.Duration {
+	return b.cur.Pause()
+}
+
+func (b *backoff) Reset() {
+	b.cur = b.initial
+}
+
+// Call is the variant of retry.Call, which takes the gax.Backoff parameters.
+func Call(ctx context.Context, bo gax.Backoff, isRetryable func(error) bool, f func() error, opts ...retry.Option) error {
+	return retry.Call(ctx, Backoff(bo), isRetryable, f, opts...)
+}
+
+// CallContext is the variant of retry.CallContext, which takes the gax.Backoff parameters.
+func CallContext(ctx context.Context, bo gax.Backoff, isRetryable func(error) bool, f func(context.Context) error, opts ...retry.Option) error {
+	return retry.CallContext(ctx, Backoff(bo), isRetryable, f, opts...)
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gaxretry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/googleapis/gax-go/v2"
+)
+
+var errRetry = errors.New("retry")
+
+func TestBackoff(t *testing.T) {
+	bo := Backoff(gax.Backoff{Initial: 10 * time.Millisecond, Max: 40 * time.Millisecond, Multiplier: 2})
+	for i, max := range []time.Duration{10, 20, 40, 40} {
+		if d := bo.Pause(); d <= 0 || d > max*time.Millisecond {
+			t.Errorf("pause %d: got %v, want up to %v", i, d, max*time.Millisecond)
+		}
+	}
+	bo.Reset()
+	if d := bo.Pause(); d <= 0 || d > 10*time.Millisecond {
+		t.Errorf("pause after reset: got %v, want up to 10ms", d)
+	}
+}
+
+func TestCall(t *testing.T) {
+	retryable := func(err error) bool { return err == errRetry }
+	gotCount := 0
+	err := Call(context.Background(), gax.Backoff{Initial: time.Millisecond}, retryable, func() error {
+		gotCount++
+		if gotCount < 3 {
+			return errRetry
+		}
+		return nil
+	})
+	if err != nil || gotCount != 3 {
+		t.Errorf("got %v after %d calls, want nil after 3", err, gotCount)
+	}
+
+	gotCount = 0
+	err = CallContext(context.Background(), gax.Backoff{Initial: time.Millisecond}, retryable, func(context.Context) error {
+		gotCount++
+		if gotCount < 2 {
+			return errRetry
+		}
+		return nil
+	})
+	if err != nil || gotCount != 2 {
+		t.Errorf("got %v after %d calls, want nil after 2", err, gotCount)
+	}
+}
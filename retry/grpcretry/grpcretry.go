@@ -0,0 +1,77 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package grpcretry adapts the gRPC errors to the retry package.
+// It is a separate module, so that the retry package and its users do not depend on gRPC.
+package grpcretry
+
+import (
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/blockysource/go-pkg/retry"
+)
+
+// RetryAfter returns the delay of the RetryInfo detail of the gRPC status of err.
+func RetryAfter(err error) (time.Duration, bool) {
+	s, ok := status.FromError(err)
+	if !ok || s == nil {
+		return 0, false
+	}
+	for _, d := range s.Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			if err := info.GetRetryDelay().CheckValid(); err != nil {
+				return 0, false
+			}
+			return info.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+// WrapError returns err wrapped with the delay of its RetryInfo detail,
+// so that the retry loop honors it, or err itself, if it has no such detail.
+// The wrapped error keeps the gRPC status, status.FromError and status.Code see through it.
+func WrapError(err error) error {
+	if d, ok := RetryAfter(err); ok {
+		return retry.WithRetryAfter(err, d)
+	}
+	return err
+}
+
+// DefaultCodes are the codes retried by the Codes classifier, when none are given.
+var DefaultCodes = []codes.Code{codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded}
+
+// Codes returns the classifier retrying the gRPC status errors with the codes, DefaultCodes if none are given.
+// The errors without the gRPC status, including the context errors, are not retried.
+func Codes(retryable ...codes.Code) retry.Classifier {
+	if len(retryable) == 0 {
+		retryable = DefaultCodes
+	}
+	return func(err error) bool {
+		s, ok := status.FromError(err)
+		if !ok || s == nil {
+			return false
+		}
+		for _, c := range retryable {
+			if s.Code() == c {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcretry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/blockysource/go-pkg/retry"
+)
+
+func TestRetryAfter(t *testing.T) {
+	s, err := status.New(codes.ResourceExhausted, "quota exceeded").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(30 * time.Second)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcErr := s.Err()
+
+	if d, ok := RetryAfter(grpcErr); !ok || d != 30*time.Second {
+		t.Errorf("RetryAfter: got %v, %v", d, ok)
+	}
+	if _, ok := RetryAfter(status.Error(codes.Unavailable, "down")); ok {
+		t.Error("RetryAfter of the status without RetryInfo succeeded")
+	}
+	if _, ok := RetryAfter(errors.New("plain")); ok {
+		t.Error("RetryAfter of the plain error succeeded")
+	}
+
+	wrapped := WrapError(grpcErr)
+	if d, ok := retry.RetryAfter(wrapped); !ok || d != 30*time.Second {
+		t.Errorf("retry.RetryAfter of the wrapped error: got %v, %v", d, ok)
+	}
+	if status.Code(wrapped) != codes.ResourceExhausted {
+		t.Errorf("wrapped error lost the status code: %v", status.Code(wrapped))
+	}
+}
+
+func TestCodes(t *testing.T) {
+	for _, test := range []struct {
+		codes []codes.Code
+		err   error
+		want  bool
+	}{
+		{nil, status.Error(codes.Unavailable, "down"), true},
+		{nil, fmt.Errorf("call: %w", status.Error(codes.Aborted, "conflict")), true},
+		{nil, status.Error(codes.InvalidArgument, "bad"), false},
+		{nil, context.DeadlineExceeded, false},
+		{nil, nil, false},
+		{[]codes.Code{codes.Internal}, status.Error(codes.Internal, "oops"), true},
+		{[]codes.Code{codes.Internal}, status.Error(codes.Unavailable, "down"), false},
+	} {
+		if got := Codes(test.codes...)(test.err); got != test.want {
+			t.Errorf("Codes(%v)(%v): got %v, want %v", test.codes, test.err, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxHistory is the maximum number of the attempts kept in the History, the older ones, but the first, are dropped.
+const maxHistory = 64
+
+// Attempt is the record of a failed call of the function being retried.
+type Attempt struct {
+	Number   int           // The number of the attempt, starting at 1.
+	Start    time.Time     // The start of the call.
+	Duration time.Duration // The duration of the call.
+	Err      error         // The error returned by the call.
+	Delay    time.Duration // The pause after the call, zero if it was not retried.
+}
+
+// History is the list of the failed attempts, in the order of the calls.
+// It keeps the first attempt, and the last 63 ones, the numbers of the attempts reveal the dropped ones.
+type History []Attempt
+
+// Dropped returns the number of the attempts dropped from the history.
+func (h History) Dropped() int {
+	if len(h) == 0 {
+		return 0
+	}
+	return h[len(h)-1].Number - h[0].Number + 1 - len(h)
+}
+
+// Errors returns the errors of the attempts, the last one first.
+func (h History) Errors() []error {
+	errs := make([]error, 0, len(h))
+	for i := len(h) - 1; i >= 0; i-- {
+		errs = append(errs, h[i].Err)
+	}
+	return errs
+}
+
+// Elapsed returns the time from the start of the first attempt to the end of the last one.
+func (h History) Elapsed() time.Duration {
+	if len(h) == 0 {
+		return 0
+	}
+	last := h[len(h)-1]
+	return last.Start.Add(last.Duration).Sub(h[0].Start)
+}
+
+// String returns the compact summary of the attempts, grouping the consecutive attempts with the same error,
+// i.e. "3 attempts in 2s: [1-2] unavailable; [3] deadline exceeded".
+// The dropped attempts are reported as such, i.e. "[2-11] dropped".
+func (h History) String() string {
+	if len(h) == 0 {
+		return "no attempts"
+	}
+	var sb strings.Builder
+	n := h[len(h)-1].Number
+	if n == 1 {
+		sb.WriteString("1 attempt")
+	} else {
+		fmt.Fprintf(&sb, "%d attempts", n)
+	}
+	fmt.Fprintf(&sb, " in %v:", h.Elapsed())
+	for i := 0; i < len(h); {
+		j := i + 1
+		for j < len(h) && h[j].Number == h[j-1].Number+1 && errorString(h[j].Err) == errorString(h[i].Err) {
+			j++
+		}
+		if i > 0 {
+			sb.WriteByte(';')
+			if prev := h[i-1].Number; h[i].Number > prev+1 {
+				if h[i].Number == prev+2 {
+					fmt.Fprintf(&sb, " [%d] dropped;", prev+1)
+				} else {
+					fmt.Fprintf(&sb, " [%d-%d] dropped;", prev+1, h[i].Number-1)
+				}
+			}
+		}
+		if first, last := h[i].Number, h[j-1].Number; first == last {
+			fmt.Fprintf(&sb, " [%d] %s", first, errorString(h[i].Err))
+		} else {
+			fmt.Fprintf(&sb, " [%d-%d] %s", first, last, errorString(h[i].Err))
+		}
+		i = j
+	}
+	return sb.String()
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return err.Error()
+}
+
+// appendAttempt appends the attempt to the history, dropping the oldest one but the first, if it is full.
+// The first attempt is kept for the start of the Elapsed time.
+func appendAttempt(h History, a Attempt) History {
+	if len(h) < maxHistory {
+		return append(h, a)
+	}
+	copy(h[1:], h[2:])
+	h[len(h)-1] = a
+	return h
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type codeError struct {
+	code int
+}
+
+func (e *codeError) Error() string {
+	return "code " + strconv.Itoa(e.code)
+}
+
+func TestHistory(t *testing.T) {
+	start := time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC)
+	now := start
+	errs := []error{&codeError{code: 1}, errRetry, errRetry, &os.PathError{Op: "open", Path: "x", Err: os.ErrNotExist}}
+	gotCount := 0
+	_, err := Do(context.Background(), func(context.Context) (int, error) {
+		now = now.Add(100 * time.Millisecond)
+		gotCount++
+		return 0, errs[gotCount-1]
+	},
+		WithBackoff(NewConstantBackoff(time.Second)),
+		WithMaxAttempts(4),
+		func(c *config) {
+			c.now = func() time.Time { return now }
+			c.sleep = func(_ context.Context, d time.Duration) error { now = now.Add(d); return nil }
+		},
+	)
+	var budgetErr *BudgetError
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("got %v, want a BudgetError", err)
+	}
+	h := budgetErr.History
+	if len(h) != 4 {
+		t.Fatalf("history: got %d attempts, want 4", len(h))
+	}
+	for i, a := range h {
+		wantDelay := time.Second
+		if i == 3 {
+			wantDelay = 0
+		}
+		if a.Number != i+1 || a.Err != errs[i] || a.Duration != 100*time.Millisecond || a.Delay != wantDelay ||
+			!a.Start.Equal(start.Add(time.Duration(i)*1100*time.Millisecond)) {
+			t.Errorf("attempt %d: got %+v", i, a)
+		}
+	}
+
+	// The errors of all the attempts are reachable.
+	var codeErr *codeError
+	if !errors.As(err, &codeErr) || codeErr.code != 1 {
+		t.Errorf("errors.As of the first attempt error failed: %v", err)
+	}
+	if !errors.Is(err, os.ErrNotExist) || !errors.Is(err, errRetry) {
+		t.Errorf("errors.Is of the attempt errors failed: %v", err)
+	}
+
+	want := "4 attempts in 3.4s: [1] code 1; [2-3] retry; [4] open x: file does not exist"
+	if got := h.String(); got != want {
+		t.Errorf("summary:\ngot  %q\nwant %q", got, want)
+	}
+	if !strings.HasSuffix(err.Error(), want) {
+		t.Errorf("error does not include the summary: %v", err)
+	}
+}
+
+func TestContextErrorHistory(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gotCount := 0
+	_, err := Do(ctx, func(context.Context) (int, error) {
+		gotCount++
+		if gotCount == 3 {
+			cancel()
+		}
+		return 0, &codeError{code: gotCount}
+	}, noSleep)
+	var cerr *ContextError
+	if !errors.As(err, &cerr) || len(cerr.History) != 3 || cerr.FuncErr != cerr.History[2].Err {
+		t.Fatalf("got %v, want a ContextError with 3 attempts", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("errors.Is of the context error failed: %v", err)
+	}
+	// errors.As finds the last attempt error first.
+	var codeErr *codeError
+	if !errors.As(err, &codeErr) || codeErr.code != 3 {
+		t.Errorf("errors.As: got %v, want code 3", codeErr)
+	}
+}
+
+func TestHistoryLimit(t *testing.T) {
+	start := time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC)
+	var h History
+	for i := 1; i <= maxHistory+10; i++ {
+		h = appendAttempt(h, Attempt{Number: i, Start: start.Add(time.Duration(i-1) * time.Second), Duration: time.Second, Err: errRetry})
+	}
+	if len(h) != maxHistory || h[0].Number != 1 || h[1].Number != 12 || h[len(h)-1].Number != maxHistory+10 {
+		t.Errorf("got %d attempts %d, %d to %d", len(h), h[0].Number, h[1].Number, h[len(h)-1].Number)
+	}
+	if got := h.Dropped(); got != 10 {
+		t.Errorf("Dropped: got %d, want 10", got)
+	}
+	if got := h.Elapsed(); got != 74*time.Second {
+		t.Errorf("Elapsed: got %v, want 1m14s", got)
+	}
+	if got, want := h.String(), "74 attempts in 1m14s: [1] retry; [2-11] dropped; [12-74] retry"; got != want {
+		t.Errorf("summary: got %q, want %q", got, want)
+	}
+}
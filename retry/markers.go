@@ -0,0 +1,114 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+)
+
+// RetryableError is implemented by the errors which know whether they should be retried.
+// The retry loop honors it before the retryable classifier set with WithRetryable, or passed to Call.
+type RetryableError interface {
+	error
+	// Retryable reports whether the failed call should be retried.
+	Retryable() bool
+}
+
+// TemporaryError is implemented by the errors which report whether they are temporary, i.e. the net.Error.
+type TemporaryError interface {
+	error
+	// Temporary reports whether the error is temporary.
+	Temporary() bool
+}
+
+// Retryable reports whether err is marked as retryable, and whether it is marked at all,
+// by the first error in the err tree implementing RetryableError, i.e. wrapped by Permanent or Transient.
+// Otherwise, the TemporaryError reporting Temporary() == true marks it as retryable, except for
+// the context.DeadlineExceeded. The Temporary() == false, which is ill-defined by the net.Error, is not a mark.
+//
+// The retry loop honors the RetryableError marks, and the TemporaryError marks only with WithTemporary,
+// so that the temporary errors do not override the classifier by default.
+func Retryable(err error) (retryable, ok bool) {
+	if retryable, ok = retryableMark(err); ok {
+		return retryable, ok
+	}
+	return temporaryMark(err), temporaryMark(err)
+}
+
+func retryableMark(err error) (retryable, ok bool) {
+	var re RetryableError
+	if errors.As(err, &re) {
+		return re.Retryable(), true
+	}
+	return false, false
+}
+
+func temporaryMark(err error) bool {
+	var te TemporaryError
+	return errors.As(err, &te) && te.Temporary() && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// WithTemporary makes the retry loop retry the errors reporting Temporary() == true, see TemporaryError,
+// before consulting the classifier. The RetryableError marks still take precedence.
+func WithTemporary() Option {
+	return func(c *config) {
+		c.temporary = true
+	}
+}
+
+// Permanent returns the error wrapping err, marked not to be retried, or nil if err is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &markedError{err: err, retryable: false}
+}
+
+// Transient returns the error wrapping err, marked to be retried, or nil if err is nil.
+func Transient(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &markedError{err: err, retryable: true}
+}
+
+type markedError struct {
+	err       error
+	retryable bool
+}
+
+func (e *markedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *markedError) Unwrap() error {
+	return e.err
+}
+
+func (e *markedError) Retryable() bool {
+	return e.retryable
+}
+
+// retryable reports whether err should be retried, by its mark, or the retryable classifier.
+func (c *config) retryable(err error) bool {
+	if retryable, ok := retryableMark(err); ok {
+		return retryable
+	}
+	if c.temporary && temporaryMark(err) {
+		return true
+	}
+	return c.isRetryable(err)
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type retryableError bool
+
+func (e retryableError) Error() string   { return fmt.Sprintf("retryable: %v", bool(e)) }
+func (e retryableError) Retryable() bool { return bool(e) }
+
+type temporaryError bool
+
+func (e temporaryError) Error() string   { return fmt.Sprintf("temporary: %v", bool(e)) }
+func (e temporaryError) Temporary() bool { return bool(e) }
+
+func TestRetryable(t *testing.T) {
+	for _, test := range []struct {
+		err                   error
+		wantRetryable, wantOK bool
+	}{
+		{errRetry, false, false},
+		{Permanent(errRetry), false, true},
+		{Transient(errNoRetry), true, true},
+		{fmt.Errorf("wrapped: %w", Transient(errNoRetry)), true, true},
+		{Permanent(Transient(errNoRetry)), false, true},
+		{retryableError(true), true, true},
+		{retryableError(false), false, true},
+		{temporaryError(true), true, true},
+		{Permanent(temporaryError(true)), false, true},
+		{temporaryError(false), false, false},
+		{context.DeadlineExceeded, false, false},
+	} {
+		retryable, ok := Retryable(test.err)
+		if retryable != test.wantRetryable || ok != test.wantOK {
+			t.Errorf("Retryable(%v): got %v, %v, want %v, %v", test.err, retryable, ok, test.wantRetryable, test.wantOK)
+		}
+	}
+	if Permanent(nil) != nil || Transient(nil) != nil {
+		t.Error("marked nil error is not nil")
+	}
+}
+
+func TestMarkedErrors(t *testing.T) {
+	t.Run("permanent", func(t *testing.T) {
+		gotCount := 0
+		err := call(context.Background(), nil, retryable, func() error {
+			gotCount++
+			return Permanent(errRetry)
+		}, nil)
+		if !errors.Is(err, errRetry) || gotCount != 1 {
+			t.Errorf("got %v after %d calls, want errRetry after 1", err, gotCount)
+		}
+		if retryable, _ := Retryable(err); retryable {
+			t.Errorf("permanent error is retryable")
+		}
+	})
+	t.Run("transient", func(t *testing.T) {
+		gotCount := 0
+		_, err := Do(context.Background(), func(context.Context) (int, error) {
+			gotCount++
+			if gotCount < 3 {
+				return 0, Transient(errNoRetry)
+			}
+			return 0, errNoRetry
+		}, WithRetryable(retryable), noSleep)
+		if err != errNoRetry || gotCount != 3 {
+			t.Errorf("got %v after %d calls, want errNoRetry after 3", err, gotCount)
+		}
+	})
+	t.Run("temporary is classified", func(t *testing.T) {
+		gotCount := 0
+		err := call(context.Background(), nil, func(error) bool { return false }, func() error {
+			gotCount++
+			return temporaryError(true)
+		}, nil)
+		if err != temporaryError(true) || gotCount != 1 {
+			t.Errorf("got %v after %d calls, want the temporary error after 1", err, gotCount)
+		}
+	})
+	t.Run("temporary with option", func(t *testing.T) {
+		gotCount := 0
+		err := call(context.Background(), nil, func(error) bool { return false }, func() error {
+			gotCount++
+			if gotCount < 3 {
+				return temporaryError(true)
+			}
+			return Permanent(temporaryError(true))
+		}, func(context.Context, time.Duration) error { return nil }, WithTemporary())
+		if !errors.Is(err, temporaryError(true)) || gotCount != 3 {
+			t.Errorf("got %v after %d calls, want the temporary error after 3", err, gotCount)
+		}
+	})
+}
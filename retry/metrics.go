@@ -0,0 +1,109 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"expvar"
+	"time"
+)
+
+// Metrics records the retry metrics, it is implemented by the adapters of the metrics libraries.
+// The methods may be called concurrently.
+type Metrics interface {
+	// AddAttempt counts a call of the function being retried.
+	AddAttempt()
+	// AddRetry counts a retry, which pauses for the delay.
+	AddRetry(delay time.Duration)
+	// AddGiveUp counts the retries which stopped with an error.
+	AddGiveUp()
+}
+
+// MetricsObserver returns the Observer recording the events in the metrics.
+func MetricsObserver(m Metrics) Observer {
+	return metricsObserver{m: m}
+}
+
+type metricsObserver struct {
+	m Metrics
+}
+
+func (o metricsObserver) OnAttempt(context.Context, int) {
+	o.m.AddAttempt()
+}
+
+func (o metricsObserver) OnRetry(_ context.Context, _ int, _ error, delay time.Duration) {
+	o.m.AddRetry(delay)
+}
+
+func (o metricsObserver) OnGiveUp(context.Context, int, error) {
+	o.m.AddGiveUp()
+}
+
+// ExpvarMetrics is the Metrics published with the expvar package, as a map with the keys:
+// "attempts", "retries" and "give_ups" counting the events, and "delay_seconds" summing the delays.
+type ExpvarMetrics struct {
+	attempts, retries, giveUps expvar.Int
+	delay                      expvar.Float
+}
+
+// NewExpvarMetrics creates a new ExpvarMetrics, published under the name.
+// If the name is already published, it panics, as expvar.Publish.
+func NewExpvarMetrics(name string) *ExpvarMetrics {
+	m := &ExpvarMetrics{}
+	vars := new(expvar.Map)
+	vars.Set("attempts", &m.attempts)
+	vars.Set("retries", &m.retries)
+	vars.Set("give_ups", &m.giveUps)
+	vars.Set("delay_seconds", &m.delay)
+	expvar.Publish(name, vars)
+	return m
+}
+
+// AddAttempt counts a call of the function being retried.
+func (m *ExpvarMetrics) AddAttempt() {
+	m.attempts.Add(1)
+}
+
+// AddRetry counts a retry, and adds its delay.
+func (m *ExpvarMetrics) AddRetry(delay time.Duration) {
+	m.retries.Add(1)
+	m.delay.Add(delay.Seconds())
+}
+
+// AddGiveUp counts the retries which stopped with an error.
+func (m *ExpvarMetrics) AddGiveUp() {
+	m.giveUps.Add(1)
+}
+
+// Attempts returns the number of the attempts.
+func (m *ExpvarMetrics) Attempts() int64 {
+	return m.attempts.Value()
+}
+
+// Retries returns the number of the retries.
+func (m *ExpvarMetrics) Retries() int64 {
+	return m.retries.Value()
+}
+
+// GiveUps returns the number of the retries which stopped with an error.
+func (m *ExpvarMetrics) GiveUps() int64 {
+	return m.giveUps.Value()
+}
+
+// Delay returns the sum of the delays.
+func (m *ExpvarMetrics) Delay() time.Duration {
+	return time.Duration(m.delay.Value() * float64(time.Second))
+}
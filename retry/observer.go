@@ -0,0 +1,87 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"time"
+)
+
+// Observer is notified of the events of the retry loop, i.e. to log or measure the retries.
+// The methods are called synchronously by the retry loop, thus they should return quickly.
+type Observer interface {
+	// OnAttempt is called before each call of f, with the number of the attempt, starting at 1.
+	OnAttempt(ctx context.Context, attempt int)
+	// OnRetry is called after the failed attempt, which is going to be retried after the delay.
+	OnRetry(ctx context.Context, attempt int, err error, delay time.Duration)
+	// OnGiveUp is called when the retries stop with the error returned to the caller,
+	// after the given number of attempts, which is zero if the context was done on entry.
+	OnGiveUp(ctx context.Context, attempts int, err error)
+}
+
+// Hooks is the Observer calling its non-nil functions.
+type Hooks struct {
+	Attempt func(ctx context.Context, attempt int)
+	Retry   func(ctx context.Context, attempt int, err error, delay time.Duration)
+	GiveUp  func(ctx context.Context, attempts int, err error)
+}
+
+// OnAttempt calls the Attempt hook, if set.
+func (h Hooks) OnAttempt(ctx context.Context, attempt int) {
+	if h.Attempt != nil {
+		h.Attempt(ctx, attempt)
+	}
+}
+
+// OnRetry calls the Retry hook, if set.
+func (h Hooks) OnRetry(ctx context.Context, attempt int, err error, delay time.Duration) {
+	if h.Retry != nil {
+		h.Retry(ctx, attempt, err, delay)
+	}
+}
+
+// OnGiveUp calls the GiveUp hook, if set.
+func (h Hooks) OnGiveUp(ctx context.Context, attempts int, err error) {
+	if h.GiveUp != nil {
+		h.GiveUp(ctx, attempts, err)
+	}
+}
+
+// WithObserver adds the observer of the retry loop. The observers are notified in the order they were added.
+func WithObserver(o Observer) Option {
+	return func(c *config) {
+		if o != nil {
+			c.observers = append(c.observers, o)
+		}
+	}
+}
+
+func (c *config) onAttempt(ctx context.Context, attempt int) {
+	for _, o := range c.observers {
+		o.OnAttempt(ctx, attempt)
+	}
+}
+
+func (c *config) onRetry(ctx context.Context, attempt int, err error, delay time.Duration) {
+	for _, o := range c.observers {
+		o.OnRetry(ctx, attempt, err, delay)
+	}
+}
+
+func (c *config) onGiveUp(ctx context.Context, attempts int, err error) {
+	for _, o := range c.observers {
+		o.OnGiveUp(ctx, attempts, err)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeMetrics is the Metrics counting the events.
+type fakeMetrics struct {
+	attempts, retries, giveUps int
+	delay                      time.Duration
+}
+
+func (m *fakeMetrics) AddAttempt()                  { m.attempts++ }
+func (m *fakeMetrics) AddRetry(delay time.Duration) { m.retries++; m.delay += delay }
+func (m *fakeMetrics) AddGiveUp()                   { m.giveUps++ }
+
+func TestObserver(t *testing.T) {
+	var events []string
+	hooks := Hooks{
+		Attempt: func(_ context.Context, attempt int) {
+			events = append(events, fmt.Sprintf("attempt %d", attempt))
+		},
+		Retry: func(_ context.Context, attempt int, err error, delay time.Duration) {
+			events = append(events, fmt.Sprintf("retry %d: %v after %v", attempt, err, delay))
+		},
+		GiveUp: func(_ context.Context, attempts int, err error) {
+			events = append(events, fmt.Sprintf("give up after %d: %v", attempts, err))
+		},
+	}
+	metrics := &fakeMetrics{}
+
+	gotCount := 0
+	_, err := Do(context.Background(), func(context.Context) (int, error) {
+		gotCount++
+		if gotCount < 3 {
+			return 0, errRetry
+		}
+		return 0, errNoRetry
+	}, WithRetryable(retryable), WithBackoff(NewConstantBackoff(time.Second)),
+		WithObserver(hooks), WithObserver(MetricsObserver(metrics)), noSleep)
+	if err != errNoRetry {
+		t.Fatalf("got %v, want errNoRetry", err)
+	}
+
+	want := []string{
+		"attempt 1",
+		"retry 1: retry after 1s",
+		"attempt 2",
+		"retry 2: retry after 1s",
+		"attempt 3",
+		"give up after 3: no retry",
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events:\ngot  %q\nwant %q", events, want)
+	}
+	if *metrics != (fakeMetrics{attempts: 3, retries: 2, giveUps: 1, delay: 2 * time.Second}) {
+		t.Errorf("metrics: got %+v", *metrics)
+	}
+}
+
+// expvarSeq makes the names of the published metrics unique, as the tests may run repeatedly.
+var expvarSeq atomic.Int32
+
+func TestExpvarMetrics(t *testing.T) {
+	name := fmt.Sprintf("retry_test_metrics_%d", expvarSeq.Add(1))
+	m := NewExpvarMetrics(name)
+	m.AddAttempt()
+	m.AddAttempt()
+	m.AddRetry(1500 * time.Millisecond)
+	m.AddGiveUp()
+	if m.Attempts() != 2 || m.Retries() != 1 || m.GiveUps() != 1 || m.Delay() != 1500*time.Millisecond {
+		t.Errorf("metrics: got %d attempts, %d retries, %d give ups, %v delay", m.Attempts(), m.Retries(), m.GiveUps(), m.Delay())
+	}
+	got := expvar.Get(name).String()
+	for _, want := range []string{`"attempts": 2`, `"retries": 1`, `"give_ups": 1`, `"delay_seconds": 1.5`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expvar: got %s, want %s", got, want)
+		}
+	}
+}
+
+func TestObserverSuccessAndCancel(t *testing.T) {
+	var giveUps []error
+	hooks := Hooks{GiveUp: func(_ context.Context, _ int, err error) { giveUps = append(giveUps, err) }}
+
+	if _, err := Do(context.Background(), func(context.Context) (int, error) { return 1, nil }, WithObserver(hooks)); err != nil {
+		t.Fatal(err)
+	}
+	if len(giveUps) != 0 {
+		t.Errorf("give up on success: %v", giveUps)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Do(ctx, func(context.Context) (int, error) { return 1, nil }, WithObserver(hooks))
+	var cerr *ContextError
+	if len(giveUps) != 1 || !errors.As(giveUps[0], &cerr) || giveUps[0] != err {
+		t.Errorf("give up on canceled context: %v", giveUps)
+	}
+}
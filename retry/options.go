@@ -0,0 +1,166 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Option configures the retries of Do.
+type Option func(*config)
+
+type config struct {
+	backoff        Backoff
+	newBackoff     func() Backoff
+	isRetryable    func(error) bool
+	maxAttempts    int
+	maxElapsed     time.Duration
+	attemptTimeout time.Duration
+	attemptFrac    float64
+	deadlineGuard  bool
+	maxRetryAfter  time.Duration
+	observers      []Observer
+	temporary      bool
+
+	// Split out for testing.
+	sleep func(context.Context, time.Duration) error
+	now   func() time.Time
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		isRetryable:   isNotContextError,
+		maxRetryAfter: DefaultMaxRetryAfter,
+		sleep:         sleep,
+		now:           time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// isNotContextError is the default retryable classifier, which retries every error but the context errors.
+func isNotContextError(err error) bool {
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// WithBackoff sets the backoff controlling the pause between the attempts, DefaultBackoff by default.
+// Each Do call resets the backoff before the first attempt, thus it must not be shared by concurrent calls.
+func WithBackoff(bo Backoff) Option {
+	return func(c *config) {
+		c.backoff = bo
+	}
+}
+
+// WithBackoffFunc sets the function creating a new backoff for each Do call,
+// so that the options can be shared by the concurrent calls. It takes precedence over WithBackoff.
+func WithBackoffFunc(newBackoff func() Backoff) Option {
+	return func(c *config) {
+		c.newBackoff = newBackoff
+	}
+}
+
+// WithRetryable sets the function reporting whether the error returned by f should be retried.
+func WithRetryable(isRetryable func(error) bool) Option {
+	return func(c *config) {
+		c.isRetryable = isRetryable
+	}
+}
+
+// WithMaxAttempts limits the number of calls of f, including the first one. Zero means no limit.
+// The retries stopped by the limit return a BudgetError.
+func WithMaxAttempts(n int) Option {
+	return func(c *config) {
+		c.maxAttempts = n
+	}
+}
+
+// WithMaxElapsedTime stops the retries, when the next attempt would start after d since the first one.
+// Zero means no limit. The retries stopped by the limit return a BudgetError.
+func WithMaxElapsedTime(d time.Duration) Option {
+	return func(c *config) {
+		c.maxElapsed = d
+	}
+}
+
+// WithAttemptTimeout limits each call of f to d, by the timeout of the context it receives.
+// Zero means no limit, other than the context passed to Do.
+// The error of the call which exceeded the timeout is retried, regardless of the classifier.
+func WithAttemptTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.attemptTimeout = d
+	}
+}
+
+// WithDeadlineGuard stops the retries with a BudgetError, instead of sleeping,
+// when the next attempt would start after the context deadline, and thus could not succeed.
+// Without the guard, the sleep is interrupted by the deadline, and a ContextError is returned.
+func WithDeadlineGuard() Option {
+	return func(c *config) {
+		c.deadlineGuard = true
+	}
+}
+
+// WithAttemptTimeoutFraction limits each call of f to the fraction, in range (0, 1], of the time remaining
+// until the deadline of the context passed to Do, so that a single hung call does not consume the whole deadline.
+// It has no effect, if the context has no deadline. Combined with WithAttemptTimeout, the shorter timeout applies.
+// The error of the call which exceeded the timeout is retried, regardless of the classifier.
+func WithAttemptTimeoutFraction(fraction float64) Option {
+	return func(c *config) {
+		c.attemptFrac = fraction
+	}
+}
+
+// attemptTimeoutFor returns the timeout of the next attempt, or zero if it is not limited.
+func (c *config) attemptTimeoutFor(ctx context.Context) time.Duration {
+	timeout := c.attemptTimeout
+	if deadline, ok := ctx.Deadline(); ok && c.attemptFrac > 0 && c.attemptFrac <= 1 {
+		frac := time.Duration(c.attemptFrac * float64(deadline.Sub(c.now())))
+		if frac <= 0 {
+			// The context is about to expire, it limits the attempt by itself.
+			return timeout
+		}
+		if timeout <= 0 || frac < timeout {
+			timeout = frac
+		}
+	}
+	return timeout
+}
+
+// WithMaxRetryAfter caps the delay hinted by the errors implementing RetryAfterError.
+// The default is DefaultMaxRetryAfter, zero means no cap, and a negative value ignores the hints.
+func WithMaxRetryAfter(d time.Duration) Option {
+	return func(c *config) {
+		c.maxRetryAfter = d
+	}
+}
+
+// pause returns the pause before the next attempt, hinted by the error or the backoff.
+func (c *config) pause(bo Backoff, err error) time.Duration {
+	pause := bo.Pause()
+	if c.maxRetryAfter < 0 {
+		return pause
+	}
+	if hint, ok := RetryAfter(err); ok && hint > 0 {
+		pause = hint
+		if c.maxRetryAfter > 0 && pause > c.maxRetryAfter {
+			pause = c.maxRetryAfter
+		}
+	}
+	return pause
+}
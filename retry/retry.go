@@ -18,10 +18,9 @@ package retry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
-
-	"github.com/googleapis/gax-go/v2"
 )
 
 // Call calls the supplied function f repeatedly, using the isRetryable function and
@@ -35,46 +34,228 @@ import (
 // When f returns an error for which isRetryable returns true, Call sleeps for the
 // provided backoff value and invokes f again.
 //
+// The errors marked with Permanent or Transient, or implementing RetryableError,
+// are not passed to isRetryable, see Retryable.
+//
 // When the provided context is done, Retry returns a ContextError that includes both
 // ctx.Error() and the last error returned by f, or nil if there isn't one.
-func Call(ctx context.Context, bo gax.Backoff, isRetryable func(error) bool, f func() error) error {
-	return call(ctx, bo, isRetryable, f, gax.Sleep)
+//
+// The options, like WithMaxAttempts, limit the retries further, see Do.
+// Call is a thin wrapper of Do, for the functions not returning a value.
+// A nil backoff means DefaultBackoff, the gax.Backoff is adapted by the gaxretry package.
+func Call(ctx context.Context, bo Backoff, isRetryable func(error) bool, f func() error, opts ...Option) error {
+	return call(ctx, bo, isRetryable, f, sleep, opts...)
 }
 
 // Split out for testing.
-func call(ctx context.Context, bo gax.Backoff, isRetryable func(error) bool, f func() error,
-	sleep func(context.Context, time.Duration) error) error {
+func call(ctx context.Context, bo Backoff, isRetryable func(error) bool, f func() error,
+	sleepFn func(context.Context, time.Duration) error, opts ...Option) error {
+	c := newConfig(append([]Option{WithBackoff(bo), WithRetryable(isRetryable)}, opts...))
+	c.sleep = sleepFn
+	_, err := do(ctx, c, func(context.Context) (struct{}, error) {
+		return struct{}{}, f()
+	})
+	return err
+}
+
+// CallContext is the variant of Call, which passes each call of f its own context,
+// derived from ctx and limited by the attempt timeout options, i.e. WithAttemptTimeout.
+// The error of the call which exceeded its attempt timeout is retried, regardless of isRetryable,
+// while the cancellation of ctx stops the retries immediately, see Do.
+func CallContext(ctx context.Context, bo Backoff, isRetryable func(error) bool, f func(context.Context) error, opts ...Option) error {
+	c := newConfig(append([]Option{WithBackoff(bo), WithRetryable(isRetryable)}, opts...))
+	_, err := do(ctx, c, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, f(ctx)
+	})
+	return err
+}
+
+// Do calls the supplied function f repeatedly, until it returns a value with a nil error,
+// and returns that value. The repetition is controlled by the options,
+// by default every error, but the context errors, is retried with the DefaultBackoff.
+//
+// When f returns an error for which the retryable classifier returns false, Do immediately
+// returns that error.
+// The marks of the error, see Retryable, take precedence over the classifier.
+// When the retryable error implements RetryAfterError, Do sleeps for the hinted delay,
+// instead of the backoff pause.
+//
+// When the maximum number of attempts, or the maximum elapsed time is reached,
+// or the deadline guard finds that the pause would overrun the context deadline,
+// Do returns a BudgetError that includes the last error returned by f.
+//
+// When the provided context is done, Do returns a ContextError that includes both
+// ctx.Error() and the last error returned by f, or nil if there isn't one.
+//
+// Each call of f receives its own context, limited by the attempt timeout options, if set.
+// The error of the call which exceeded its attempt timeout is retried, regardless of the classifier,
+// while the retries stop immediately with a ContextError, when the provided context is done.
+//
+// The observers set with WithObserver are notified of every attempt, retry and the final failure.
+func Do[T any](ctx context.Context, f func(context.Context) (T, error), opts ...Option) (T, error) {
+	return do(ctx, newConfig(opts), f)
+}
+
+func do[T any](ctx context.Context, c *config, f func(context.Context) (T, error)) (T, error) {
+	v, attempts, err := loop(ctx, c, f)
+	if err != nil {
+		c.onGiveUp(ctx, attempts, err)
+	}
+	return v, err
+}
+
+// loop is the retry loop of do, which also returns the number of attempts.
+func loop[T any](ctx context.Context, c *config, f func(context.Context) (T, error)) (T, int, error) {
+	var zero T
 	// Do nothing if context is done on entry.
 	if err := ctx.Err(); err != nil {
-		return &ContextError{CtxErr: err}
+		return zero, 0, &ContextError{CtxErr: err}
 	}
-	for {
-		err := f()
+	bo := c.backoff
+	if c.newBackoff != nil {
+		bo = c.newBackoff()
+	}
+	if bo == nil {
+		bo = DefaultBackoff()
+	}
+	bo.Reset()
+	start := c.now()
+	var history History
+	for attempt := 1; ; attempt++ {
+		c.onAttempt(ctx, attempt)
+		began := c.now()
+		v, err, timedOut := attemptCall(ctx, c, f)
 		if err == nil {
-			return nil
+			return v, attempt, nil
+		}
+		if !timedOut && !c.retryable(err) {
+			return zero, attempt, err
+		}
+		history = appendAttempt(history, Attempt{Number: attempt, Start: began, Duration: c.now().Sub(began), Err: err})
+		// Do not retry, if the attempt was interrupted by the provided context.
+		if cerr := ctx.Err(); cerr != nil {
+			return zero, attempt, &ContextError{CtxErr: cerr, FuncErr: err, History: history}
+		}
+		budgetErr := func(reason BudgetReason) error {
+			return &BudgetError{Reason: reason, Attempts: attempt, Elapsed: c.now().Sub(start), Err: err, History: history}
 		}
-		if !isRetryable(err) {
-			return err
+		if c.maxAttempts > 0 && attempt >= c.maxAttempts {
+			return zero, attempt, budgetErr(BudgetMaxAttempts)
 		}
-		if cerr := sleep(ctx, bo.Pause()); cerr != nil {
-			return &ContextError{CtxErr: cerr, FuncErr: err}
+		pause := c.pause(bo, err)
+		next := c.now().Add(pause)
+		if c.maxElapsed > 0 && next.Sub(start) >= c.maxElapsed {
+			return zero, attempt, budgetErr(BudgetMaxElapsedTime)
+		}
+		if deadline, ok := ctx.Deadline(); ok && c.deadlineGuard && !next.Before(deadline) {
+			return zero, attempt, budgetErr(BudgetDeadline)
+		}
+		c.onRetry(ctx, attempt, err, pause)
+		history[len(history)-1].Delay = pause
+		if cerr := c.sleep(ctx, pause); cerr != nil {
+			return zero, attempt, &ContextError{CtxErr: cerr, FuncErr: err, History: history}
 		}
 	}
 }
 
+// attemptCall calls f with the context limited by the attempt timeout,
+// and reports whether the attempt timeout, not the provided context, expired.
+func attemptCall[T any](ctx context.Context, c *config, f func(context.Context) (T, error)) (T, error, bool) {
+	timeout := c.attemptTimeoutFor(ctx)
+	if timeout <= 0 {
+		v, err := f(ctx)
+		return v, err, false
+	}
+	actx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	v, err := f(actx)
+	timedOut := err != nil && errors.Is(actx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+	return v, err, timedOut
+}
+
+// BudgetReason is the budget of the retries, which was exhausted.
+type BudgetReason int
+
+const (
+	// BudgetMaxAttempts is the maximum number of attempts.
+	BudgetMaxAttempts BudgetReason = iota + 1
+	// BudgetMaxElapsedTime is the maximum elapsed time.
+	BudgetMaxElapsedTime
+	// BudgetDeadline is the context deadline, which the pause before the next attempt would overrun.
+	BudgetDeadline
+)
+
+// String returns the name of the budget.
+func (r BudgetReason) String() string {
+	switch r {
+	case BudgetMaxAttempts:
+		return "maximum attempts"
+	case BudgetMaxElapsedTime:
+		return "maximum elapsed time"
+	case BudgetDeadline:
+		return "context deadline"
+	}
+	return fmt.Sprintf("BudgetReason(%d)", int(r))
+}
+
+// A BudgetError is returned when the retries stop, because their budget was exhausted,
+// while the context is not done yet. It contains the last error from the function being retried,
+// and the history of the failed attempts.
+type BudgetError struct {
+	Reason   BudgetReason  // The exhausted budget.
+	Attempts int           // The number of the function calls.
+	Elapsed  time.Duration // The time since the first call.
+	Err      error         // The last error obtained from the function being retried.
+	History  History       // The failed attempts, the last one is Err.
+}
+
+func (e *BudgetError) Error() string {
+	msg := fmt.Sprintf("retry budget exhausted (%v) after %d attempts in %v; last error: %v", e.Reason, e.Attempts, e.Elapsed, e.Err)
+	if len(e.History) > 1 {
+		msg += "; " + e.History.String()
+	}
+	return msg
+}
+
+// Unwrap returns the errors of the attempts, the last one first, so that errors.Is and errors.As see all of them.
+func (e *BudgetError) Unwrap() []error {
+	return unwrapHistory(e.History, e.Err)
+}
+
 // A ContextError contains both a context error (either context.Canceled or
 // context.DeadlineExceeded), and the last error from the function being retried,
-// or nil if the function was never called.
+// or nil if the function was never called, and the history of the failed attempts.
 type ContextError struct {
-	CtxErr  error // The error obtained from ctx.Err()
-	FuncErr error // The error obtained from the function being retried, or nil
+	CtxErr  error   // The error obtained from ctx.Err()
+	FuncErr error   // The error obtained from the function being retried, or nil
+	History History // The failed attempts, the last one is FuncErr.
 }
 
 func (e *ContextError) Error() string {
-	return fmt.Sprintf("%v; last error: %v", e.CtxErr, e.FuncErr)
+	msg := fmt.Sprintf("%v; last error: %v", e.CtxErr, e.FuncErr)
+	if len(e.History) > 1 {
+		msg += "; " + e.History.String()
+	}
+	return msg
 }
 
-// Is returns true iff one of the two errors held in e is equal to target.
-func (e *ContextError) Is(target error) bool {
-	return e.CtxErr == target || e.FuncErr == target
+// Unwrap returns the context error, followed by the errors of the attempts, the last one first,
+// so that errors.Is and errors.As see all of them.
+func (e *ContextError) Unwrap() []error {
+	errs := unwrapHistory(e.History, e.FuncErr)
+	if e.CtxErr == nil {
+		return errs
+	}
+	return append([]error{e.CtxErr}, errs...)
+}
+
+// unwrapHistory returns the errors of the history, the last one first, or the last error, if there is no history.
+func unwrapHistory(h History, last error) []error {
+	if len(h) == 0 {
+		if last == nil {
+			return nil
+		}
+		return []error{last}
+	}
+	return h.Errors()
 }
@@ -0,0 +1,132 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxRetryAfter is the default maximum of the delay hinted by the errors.
+const DefaultMaxRetryAfter = time.Minute
+
+// RetryAfterError is implemented by the errors, which know how long to wait before the retry,
+// i.e. from the HTTP Retry-After header. The retry loop uses the hinted delay instead of the backoff pause,
+// capped by the maximum set with WithMaxRetryAfter. The non-positive delays are ignored.
+type RetryAfterError interface {
+	error
+	// RetryAfter returns the delay before the retry.
+	RetryAfter() time.Duration
+}
+
+// RetryAfter returns the delay hinted by the first error in the err tree implementing RetryAfterError.
+func RetryAfter(err error) (time.Duration, bool) {
+	var ra RetryAfterError
+	if !errors.As(err, &ra) {
+		return 0, false
+	}
+	return ra.RetryAfter(), true
+}
+
+// WithRetryAfter returns the error wrapping err, hinting to retry after the delay.
+func WithRetryAfter(err error, delay time.Duration) error {
+	return &retryAfterError{err: err, delay: delay}
+}
+
+type retryAfterError struct {
+	err   error
+	delay time.Duration
+}
+
+func (e *retryAfterError) Error() string {
+	return fmt.Sprintf("%v (retry after %v)", e.err, e.delay)
+}
+
+func (e *retryAfterError) Unwrap() error {
+	return e.err
+}
+
+func (e *retryAfterError) RetryAfter() time.Duration {
+	return e.delay
+}
+
+// ParseRetryAfter parses the value of the HTTP Retry-After header, either the delay in seconds,
+// or the HTTP-date, which is converted to the delay since now. The dates in the past are zero delays.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		if secs > int64(1<<63-1)/int64(time.Second) {
+			secs = int64(1<<63-1) / int64(time.Second)
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	t, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if d := t.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+// HTTPRetryAfter returns the delay of the Retry-After header of the response.
+func HTTPRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	return ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+}
+
+// HTTPStatusError is the error of the HTTP response with an unexpected status code.
+// It implements RetryAfterError, if the response had the Retry-After header.
+type HTTPStatusError struct {
+	StatusCode int           // The status code of the response.
+	Status     string        // The status line of the response, i.e. "503 Service Unavailable".
+	Delay      time.Duration // The delay of the Retry-After header, if HasDelay is true.
+	HasDelay   bool          // Whether the response had the valid Retry-After header.
+}
+
+// NewHTTPStatusError creates a new HTTPStatusError of the response.
+// The response body is not read nor closed.
+func NewHTTPStatusError(resp *http.Response) *HTTPStatusError {
+	e := &HTTPStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	e.Delay, e.HasDelay = HTTPRetryAfter(resp)
+	return e
+}
+
+func (e *HTTPStatusError) Error() string {
+	status := e.Status
+	if status == "" {
+		status = strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode)
+	}
+	return "unexpected HTTP status: " + status
+}
+
+// RetryAfter returns the delay of the Retry-After header,
+// or zero if there was none, so that the backoff pause is used.
+func (e *HTTPStatusError) RetryAfter() time.Duration {
+	return e.Delay
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2023, 7, 5, 12, 0, 0, 0, time.UTC)
+	for _, test := range []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"30", 30 * time.Second, true},
+		{" 0 ", 0, true},
+		{"Wed, 05 Jul 2023 12:01:30 GMT", 90 * time.Second, true},
+		{"Wed, 05 Jul 2023 11:00:00 GMT", 0, true},
+		{"-5", 0, false},
+		{"", 0, false},
+		{"soon", 0, false},
+	} {
+		got, ok := ParseRetryAfter(test.value, now)
+		if got != test.want || ok != test.wantOK {
+			t.Errorf("ParseRetryAfter(%q): got %v, %v, want %v, %v", test.value, got, ok, test.want, test.wantOK)
+		}
+	}
+}
+
+func TestHTTPStatusError(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Status:     "429 Too Many Requests",
+		Header:     http.Header{"Retry-After": []string{"7"}},
+	}
+	err := error(NewHTTPStatusError(resp))
+	if d, ok := RetryAfter(err); !ok || d != 7*time.Second {
+		t.Errorf("RetryAfter: got %v, %v", d, ok)
+	}
+	if got, want := err.Error(), "unexpected HTTP status: 429 Too Many Requests"; got != want {
+		t.Errorf("Error: got %q, want %q", got, want)
+	}
+}
+
+func TestRetryAfterPause(t *testing.T) {
+	bo := NewConstantBackoff(time.Millisecond)
+	for _, test := range []struct {
+		desc      string
+		err       error
+		opts      []Option
+		wantPause time.Duration
+	}{
+		{
+			desc:      "hinted delay",
+			err:       WithRetryAfter(errRetry, 30*time.Second),
+			wantPause: 30 * time.Second,
+		},
+		{
+			desc:      "capped by the default maximum",
+			err:       WithRetryAfter(errRetry, time.Hour),
+			wantPause: DefaultMaxRetryAfter,
+		},
+		{
+			desc:      "capped by the maximum",
+			err:       WithRetryAfter(errRetry, 30*time.Second),
+			opts:      []Option{WithMaxRetryAfter(10 * time.Second)},
+			wantPause: 10 * time.Second,
+		},
+		{
+			desc:      "no maximum",
+			err:       WithRetryAfter(errRetry, time.Hour),
+			opts:      []Option{WithMaxRetryAfter(0)},
+			wantPause: time.Hour,
+		},
+		{
+			desc:      "hints ignored",
+			err:       WithRetryAfter(errRetry, 30*time.Second),
+			opts:      []Option{WithMaxRetryAfter(-1)},
+			wantPause: time.Millisecond,
+		},
+		{
+			desc:      "zero hint uses backoff",
+			err:       WithRetryAfter(errRetry, 0),
+			wantPause: time.Millisecond,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			var pauses []time.Duration
+			gotCount := 0
+			opts := append([]Option{
+				WithBackoff(bo),
+				WithRetryable(func(err error) bool { return errors.Is(err, errRetry) }),
+				func(c *config) {
+					c.sleep = func(_ context.Context, d time.Duration) error { pauses = append(pauses, d); return nil }
+				},
+			}, test.opts...)
+			_, err := Do(context.Background(), func(context.Context) (int, error) {
+				gotCount++
+				if gotCount < 2 {
+					return 0, test.err
+				}
+				return 0, nil
+			}, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(pauses) != 1 || pauses[0] != test.wantPause {
+				t.Errorf("pauses: got %v, want [%v]", pauses, test.wantPause)
+			}
+		})
+	}
+}
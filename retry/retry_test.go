@@ -20,8 +20,6 @@ import (
 	"os"
 	"testing"
 	"time"
-
-	"github.com/googleapis/gax-go/v2"
 )
 
 // Errors to distinguish retryable and non-retryable cases.
@@ -80,7 +78,7 @@ func TestCall(t *testing.T) {
 			sleep := func(context.Context, time.Duration) error { return nil }
 			gotCount := 0
 			f := func() error { gotCount++; return test.f(gotCount - 1) }
-			gotErr := call(context.Background(), gax.Backoff{}, test.isRetryable, f, sleep)
+			gotErr := call(context.Background(), nil, test.isRetryable, f, sleep)
 			if gotErr != test.wantErr {
 				t.Errorf("error: got %v, want %v", gotErr, test.wantErr)
 			}
@@ -98,7 +96,7 @@ func TestCallCancel(t *testing.T) {
 		cancel()
 		gotCount := 0
 		f := func() error { gotCount++; return nil }
-		gotErr := call(ctx, gax.Backoff{}, retryable, f, nil)
+		gotErr := call(ctx, nil, retryable, f, nil)
 		if gotCount != 0 {
 			t.Errorf("retry count: got %d, want 0", gotCount)
 		}
@@ -112,7 +110,7 @@ func TestCallCancel(t *testing.T) {
 		gotCount := 0
 		f := func() error { gotCount++; return errRetry }
 		sleep := func(context.Context, time.Duration) error { return context.Canceled }
-		gotErr := call(context.Background(), gax.Backoff{}, retryable, f, sleep)
+		gotErr := call(context.Background(), nil, retryable, f, sleep)
 		if gotCount != 1 {
 			t.Errorf("retry count: got %d, want 1", gotCount)
 		}
@@ -142,3 +140,170 @@ func TestErrorsIs(t *testing.T) {
 		}
 	}
 }
+
+func noSleep(c *config) {
+	c.sleep = func(context.Context, time.Duration) error { return nil }
+}
+
+func TestDo(t *testing.T) {
+	t.Run("returns value", func(t *testing.T) {
+		gotCount := 0
+		v, err := Do(context.Background(), func(context.Context) (int, error) {
+			gotCount++
+			if gotCount < 3 {
+				return 0, errRetry
+			}
+			return 42, nil
+		}, WithRetryable(retryable), noSleep)
+		if err != nil || v != 42 {
+			t.Errorf("got %v, %v, want 42, nil", v, err)
+		}
+		if gotCount != 3 {
+			t.Errorf("retry count: got %d, want 3", gotCount)
+		}
+	})
+	t.Run("default classifier", func(t *testing.T) {
+		gotCount := 0
+		_, err := Do(context.Background(), func(context.Context) (int, error) {
+			gotCount++
+			if gotCount < 2 {
+				return 0, errRetry
+			}
+			return 0, context.Canceled
+		}, noSleep)
+		if err != context.Canceled || gotCount != 2 {
+			t.Errorf("got %v after %d calls, want context.Canceled after 2", err, gotCount)
+		}
+	})
+	t.Run("max attempts", func(t *testing.T) {
+		gotCount := 0
+		_, err := Do(context.Background(), func(context.Context) (int, error) {
+			gotCount++
+			return 0, errRetry
+		}, WithMaxAttempts(4), noSleep)
+		var budgetErr *BudgetError
+		if !errors.As(err, &budgetErr) || budgetErr.Reason != BudgetMaxAttempts || budgetErr.Attempts != 4 || gotCount != 4 {
+			t.Errorf("got %v after %d calls, want maximum attempts after 4", err, gotCount)
+		}
+		if !errors.Is(err, errRetry) {
+			t.Errorf("budget error does not wrap the last error: %v", err)
+		}
+	})
+	t.Run("max elapsed time", func(t *testing.T) {
+		start := time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC)
+		now := start
+		gotCount := 0
+		_, err := Do(context.Background(), func(context.Context) (int, error) {
+			gotCount++
+			return 0, errRetry
+		},
+			WithBackoff(NewConstantBackoff(time.Second)),
+			WithMaxElapsedTime(3500*time.Millisecond),
+			func(c *config) {
+				c.now = func() time.Time { return now }
+				c.sleep = func(_ context.Context, d time.Duration) error { now = now.Add(d); return nil }
+			},
+		)
+		// The attempts start at 0s, 1s, 2s and 3s, the fifth one would start after 3.5s.
+		var budgetErr *BudgetError
+		if !errors.As(err, &budgetErr) || budgetErr.Reason != BudgetMaxElapsedTime || gotCount != 4 || now.Sub(start) != 3*time.Second {
+			t.Errorf("got %v after %d calls and %v", err, gotCount, now.Sub(start))
+		}
+		if budgetErr != nil && (budgetErr.Attempts != gotCount || budgetErr.Elapsed != now.Sub(start)) {
+			t.Errorf("budget error: got %d attempts in %v", budgetErr.Attempts, budgetErr.Elapsed)
+		}
+	})
+	t.Run("attempt timeout", func(t *testing.T) {
+		_, err := Do(context.Background(), func(ctx context.Context) (int, error) {
+			deadline, ok := ctx.Deadline()
+			if !ok || time.Until(deadline) > time.Second {
+				t.Errorf("attempt context deadline: %v, %v", deadline, ok)
+			}
+			return 0, errNoRetry
+		}, WithRetryable(retryable), WithAttemptTimeout(time.Second))
+		if err != errNoRetry {
+			t.Errorf("got %v, want errNoRetry", err)
+		}
+	})
+}
+
+func TestDeadlineGuard(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	bo := NewConstantBackoff(20 * time.Second)
+	var slept time.Duration
+	sleep := func(_ context.Context, d time.Duration) error {
+		slept += d
+		return nil
+	}
+	gotCount := 0
+	err := call(ctx, bo, retryable, func() error { gotCount++; return errRetry }, sleep,
+		WithDeadlineGuard(),
+		func(c *config) {
+			start := time.Now()
+			c.now = func() time.Time { return start.Add(slept) }
+		},
+	)
+	var budgetErr *BudgetError
+	if !errors.As(err, &budgetErr) || budgetErr.Reason != BudgetDeadline {
+		t.Fatalf("got %v, want the context deadline budget error", err)
+	}
+	// The attempts start at 0s, 20s and 40s, the fourth one would start at the deadline.
+	if budgetErr.Attempts != gotCount || gotCount != 3 {
+		t.Errorf("got %d attempts, %d calls", budgetErr.Attempts, gotCount)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("budget error is a context error: %v", err)
+	}
+}
+
+func TestCallContext(t *testing.T) {
+	t.Run("attempt timeout is retried", func(t *testing.T) {
+		gotCount := 0
+		err := CallContext(context.Background(), nil, retryable, func(ctx context.Context) error {
+			gotCount++
+			if gotCount < 3 {
+				// A hung attempt.
+				<-ctx.Done()
+				return ctx.Err()
+			}
+			return nil
+		}, WithAttemptTimeout(10*time.Millisecond), noSleep)
+		if err != nil || gotCount != 3 {
+			t.Errorf("got %v after %d calls, want nil after 3", err, gotCount)
+		}
+	})
+	t.Run("parent cancel stops", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		gotCount := 0
+		slept := false
+		err := CallContext(ctx, nil, retryable, func(ctx context.Context) error {
+			gotCount++
+			cancel()
+			<-ctx.Done()
+			return errRetry
+		}, WithAttemptTimeout(time.Minute), func(c *config) {
+			c.sleep = func(context.Context, time.Duration) error { slept = true; return nil }
+		})
+		wantErr := &ContextError{CtxErr: context.Canceled, FuncErr: errRetry}
+		if !equalContextError(err, wantErr) || gotCount != 1 || slept {
+			t.Errorf("got %v after %d calls, slept %v", err, gotCount, slept)
+		}
+	})
+	t.Run("fraction of deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		err := CallContext(ctx, nil, retryable, func(actx context.Context) error {
+			deadline, _ := actx.Deadline()
+			if remaining := time.Until(deadline); remaining > 2500*time.Millisecond || remaining < 2*time.Second {
+				t.Errorf("attempt timeout: got %v, want 2.5s", remaining)
+			}
+			return nil
+		}, WithAttemptTimeoutFraction(0.25), WithAttemptTimeout(time.Minute))
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
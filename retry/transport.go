@@ -0,0 +1,143 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultTransportMaxAttempts is the default maximum number of attempts of the Transport.
+const DefaultTransportMaxAttempts = 3
+
+// maxDrainBody is the maximum number of bytes read from the body of a failed response,
+// so that its connection can be reused. The longer bodies are closed without reading.
+const maxDrainBody = 4 << 10
+
+// IdempotencyKeyHeader is the header, which makes the request with a non-idempotent method retryable.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// Transport is the http.RoundTripper retrying the failed requests of the base transport.
+//
+// Only the requests with an idempotent method, see IdempotentMethod, or with the Idempotency-Key header, are retried,
+// and the requests with a body, only if they can rewind it with GetBody. The errors of the base transport,
+// and the responses with the status codes reported by RetryableHTTPStatus, as the *HTTPStatusError,
+// are passed to the retryable classifier. By default, it retries the NetworkErrors, including the bare EOFs
+// of the base transport, which mean that the connection was closed, and the HTTPStatusErrors(true).
+// The responses are retried after the delay of their Retry-After header, if any.
+// The failed responses are drained and closed before the retry. When the retries are exhausted,
+// the last failed response is returned, while the cancellation of the request context stops the retries with a ContextError.
+type Transport struct {
+	base http.RoundTripper
+	opts []Option
+}
+
+// NewTransport creates a new Transport retrying the requests of the base transport, http.DefaultTransport if nil.
+// The options control the retries, by default at most DefaultTransportMaxAttempts attempts are made.
+// The classifier set with WithRetryable replaces the default one, i.e. WithRetryable(NetworkErrors())
+// does not retry the failed responses.
+// The attempt timeout options are ignored, as the end of the attempt would cancel the response body,
+// the Client.Timeout, or the request context, limit the requests instead. The options are shared by the concurrent requests,
+// thus the backoff should be set with WithBackoffFunc.
+func NewTransport(base http.RoundTripper, opts ...Option) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{
+		base: base,
+		opts: append([]Option{WithMaxAttempts(DefaultTransportMaxAttempts), WithRetryable(Any(NetworkErrors(), isEOF, HTTPStatusErrors(true)))}, opts...),
+	}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.retryable(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	c := newConfig(t.opts)
+	c.attemptTimeout, c.attemptFrac = 0, 0
+
+	// The failed response is kept, until the retry is decided, or returned when the retries are exhausted.
+	var failed *http.Response
+	c.observers = append(c.observers, Hooks{Retry: func(context.Context, int, error, time.Duration) {
+		drainBody(failed)
+		failed = nil
+	}})
+
+	attempt := 0
+	resp, err := do(req.Context(), c, func(ctx context.Context) (*http.Response, error) {
+		attempt++
+		r := req
+		if attempt > 1 {
+			r = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, Permanent(err)
+				}
+				r.Body = body
+			}
+		}
+		resp, err := t.base.RoundTrip(r)
+		if err != nil {
+			return nil, err
+		}
+		if !RetryableHTTPStatus(resp.StatusCode, true) {
+			return resp, nil
+		}
+		failed = resp
+		return nil, NewHTTPStatusError(resp)
+	})
+	if err == nil {
+		return resp, nil
+	}
+	var statusErr *HTTPStatusError
+	if failed != nil && errors.As(err, &statusErr) {
+		var cerr *ContextError
+		if !errors.As(err, &cerr) {
+			return failed, nil
+		}
+		drainBody(failed)
+	}
+	return nil, err
+}
+
+// retryable reports whether the request can be retried.
+func (t *Transport) retryable(req *http.Request) bool {
+	if !IdempotentMethod(req.Method) && req.Header.Get(IdempotencyKeyHeader) == "" {
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// CloseIdleConnections closes the idle connections of the base transport, if it supports it.
+func (t *Transport) CloseIdleConnections() {
+	if ci, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		ci.CloseIdleConnections()
+	}
+}
+
+// drainBody reads the beginning of the response body, so that its connection can be reused, and closes it.
+func drainBody(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	_, _ = io.CopyN(io.Discard, resp.Body, maxDrainBody)
+	_ = resp.Body.Close()
+}
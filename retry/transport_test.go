@@ -0,0 +1,182 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer starts a server, which fails the first failures requests with the status, and then responds "ok".
+// It returns the server and the counter of the requests.
+func flakyServer(t *testing.T, failures int32, status int, header http.Header) (*httptest.Server, *atomic.Int32) {
+	var count atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := count.Add(1)
+		if r.Body != nil {
+			body, _ := io.ReadAll(r.Body)
+			if r.Method == http.MethodPost && string(body) != "payload" {
+				t.Errorf("request %d: got body %q", n, body)
+			}
+		}
+		if n <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			_, _ = io.WriteString(w, "busy")
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &count
+}
+
+func testClient(opts ...Option) *http.Client {
+	opts = append([]Option{WithBackoffFunc(func() Backoff { return NewConstantBackoff(time.Millisecond) })}, opts...)
+	return &http.Client{Transport: NewTransport(nil, opts...)}
+}
+
+func readBody(t *testing.T, resp *http.Response) string {
+	t.Helper()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+func TestTransport(t *testing.T) {
+	t.Run("classifier opts out of statuses", func(t *testing.T) {
+		srv, count := flakyServer(t, 2, http.StatusServiceUnavailable, nil)
+		resp, err := testClient(WithRetryable(NetworkErrors())).Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if body := readBody(t, resp); resp.StatusCode != http.StatusServiceUnavailable || body != "busy" || count.Load() != 1 {
+			t.Errorf("got %d %q after %d requests, want 503 busy after 1", resp.StatusCode, body, count.Load())
+		}
+	})
+	t.Run("retries idempotent request", func(t *testing.T) {
+		srv, count := flakyServer(t, 2, http.StatusServiceUnavailable, nil)
+		resp, err := testClient().Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if body := readBody(t, resp); resp.StatusCode != http.StatusOK || body != "ok" || count.Load() != 3 {
+			t.Errorf("got %d %q after %d requests, want 200 ok after 3", resp.StatusCode, body, count.Load())
+		}
+	})
+	t.Run("does not retry non-idempotent request", func(t *testing.T) {
+		srv, count := flakyServer(t, 1, http.StatusServiceUnavailable, nil)
+		resp, err := testClient().Post(srv.URL, "text/plain", strings.NewReader("payload"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if body := readBody(t, resp); resp.StatusCode != http.StatusServiceUnavailable || body != "busy" || count.Load() != 1 {
+			t.Errorf("got %d %q after %d requests, want 503 busy after 1", resp.StatusCode, body, count.Load())
+		}
+	})
+	t.Run("retries request with idempotency key and rewinds body", func(t *testing.T) {
+		srv, count := flakyServer(t, 1, http.StatusServiceUnavailable, nil)
+		req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("payload"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		resp, err := testClient().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if body := readBody(t, resp); resp.StatusCode != http.StatusOK || body != "ok" || count.Load() != 2 {
+			t.Errorf("got %d %q after %d requests, want 200 ok after 2", resp.StatusCode, body, count.Load())
+		}
+	})
+	t.Run("returns last response when exhausted", func(t *testing.T) {
+		srv, count := flakyServer(t, 10, http.StatusBadGateway, nil)
+		resp, err := testClient().Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if body := readBody(t, resp); resp.StatusCode != http.StatusBadGateway || body != "busy" || count.Load() != DefaultTransportMaxAttempts {
+			t.Errorf("got %d %q after %d requests, want 502 busy after %d", resp.StatusCode, body, count.Load(), DefaultTransportMaxAttempts)
+		}
+	})
+	t.Run("honors retry after", func(t *testing.T) {
+		srv, count := flakyServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": []string{"30"}})
+		var delays []time.Duration
+		resp, err := testClient(
+			WithMaxRetryAfter(20*time.Millisecond),
+			WithObserver(Hooks{Retry: func(_ context.Context, _ int, _ error, d time.Duration) { delays = append(delays, d) }}),
+		).Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if body := readBody(t, resp); body != "ok" || count.Load() != 2 {
+			t.Errorf("got %q after %d requests, want ok after 2", body, count.Load())
+		}
+		if len(delays) != 1 || delays[0] != 20*time.Millisecond {
+			t.Errorf("delays: got %v, want [20ms]", delays)
+		}
+	})
+	t.Run("stops on request cancel", func(t *testing.T) {
+		srv, count := flakyServer(t, 10, http.StatusServiceUnavailable, nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = testClient(
+			WithObserver(Hooks{Retry: func(context.Context, int, error, time.Duration) { cancel() }}),
+		).Do(req)
+		if !errors.Is(err, context.Canceled) || count.Load() != 1 {
+			t.Errorf("got %v after %d requests, want context.Canceled after 1", err, count.Load())
+		}
+	})
+	t.Run("retries network errors", func(t *testing.T) {
+		var count atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if count.Add(1) == 1 {
+				// Close the connection without a response.
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				_ = conn.Close()
+				return
+			}
+			_, _ = io.WriteString(w, "ok")
+		}))
+		defer srv.Close()
+		resp, err := testClient().Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if body := readBody(t, resp); body != "ok" || count.Load() != 2 {
+			t.Errorf("got %q after %d requests, want ok after 2", body, count.Load())
+		}
+	})
+}
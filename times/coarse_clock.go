@@ -0,0 +1,115 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var _ Clock = (*CoarseClock)(nil)
+
+// CoarseClock is a Clock that returns the cached time, updated by a background goroutine at the resolution.
+// Reading the time is a single atomic load, which makes it suitable for the hot paths,
+// like logging or metrics, that can tolerate the time lagging behind by up to the resolution.
+// The clock must be stopped with Stop when it is no longer needed.
+type CoarseClock struct {
+	loc        *time.Location
+	resolution time.Duration
+	now        atomic.Pointer[time.Time]
+	stop       chan struct{}
+	done       chan struct{}
+	once       sync.Once
+}
+
+// NewCoarseClock creates a new CoarseClock in given location, and starts updating it at the resolution.
+// A non-positive resolution defaults to 1 millisecond.
+func NewCoarseClock(loc *time.Location, resolution time.Duration) *CoarseClock {
+	if resolution <= 0 {
+		resolution = time.Millisecond
+	}
+	c := &CoarseClock{
+		loc:        loc,
+		resolution: resolution,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	c.update()
+	go c.run()
+	return c
+}
+
+func (c *CoarseClock) run() {
+	defer close(c.done)
+	ticker := time.NewTicker(c.resolution)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.update()
+		}
+	}
+}
+
+func (c *CoarseClock) update() {
+	now := time.Now().In(c.loc)
+	c.now.Store(&now)
+}
+
+// Stop stops updating the clock. The clock keeps returning the last cached time.
+func (c *CoarseClock) Stop() {
+	c.once.Do(func() {
+		close(c.stop)
+		<-c.done
+	})
+}
+
+// Resolution returns the update interval of the clock.
+func (c *CoarseClock) Resolution() time.Duration {
+	return c.resolution
+}
+
+// Now returns the cached current time.
+func (c *CoarseClock) Now() time.Time {
+	return *c.now.Load()
+}
+
+// Unix returns the cached current time in unix format.
+func (c *CoarseClock) Unix() int64 {
+	return c.Now().Unix()
+}
+
+// UnixNano returns the cached current time in unix format.
+func (c *CoarseClock) UnixNano() int64 {
+	return c.Now().UnixNano()
+}
+
+// Location returns the current location.
+func (c *CoarseClock) Location() *time.Location {
+	return c.loc
+}
+
+// Since returns the time elapsed since t.
+func (c *CoarseClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Until returns the time until t.
+func (c *CoarseClock) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
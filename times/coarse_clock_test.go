@@ -0,0 +1,92 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCoarseClock(t *testing.T) {
+	c := NewCoarseClock(time.UTC, time.Millisecond)
+	defer c.Stop()
+
+	if c.Location() != time.UTC || c.Now().Location() != time.UTC {
+		t.Fatalf("location: got %v", c.Now().Location())
+	}
+	if d := time.Since(c.Now()); d < 0 || d > time.Second {
+		t.Fatalf("cached time is off by %v", d)
+	}
+
+	first := c.Now()
+	deadline := time.Now().Add(time.Second)
+	for !c.Now().After(first) {
+		if time.Now().After(deadline) {
+			t.Fatal("clock is not updated")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	c.Stop()
+	stopped := c.Now()
+	time.Sleep(5 * time.Millisecond)
+	if !c.Now().Equal(stopped) {
+		t.Fatal("stopped clock is still updated")
+	}
+	// Stop is idempotent.
+	c.Stop()
+}
+
+func BenchmarkZonedClock_Now(b *testing.B) {
+	c := NewZonedClock(time.UTC)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = c.Now()
+		}
+	})
+}
+
+func BenchmarkCoarseClock_Now(b *testing.B) {
+	c := NewCoarseClock(time.UTC, time.Millisecond)
+	defer c.Stop()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = c.Now()
+		}
+	})
+}
+
+func BenchmarkZonedClock_UnixNano(b *testing.B) {
+	c := NewZonedClock(time.UTC)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = c.UnixNano()
+		}
+	})
+}
+
+func BenchmarkCoarseClock_UnixNano(b *testing.B) {
+	c := NewCoarseClock(time.UTC, time.Millisecond)
+	defer c.Stop()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = c.UnixNano()
+		}
+	})
+}
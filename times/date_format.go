@@ -0,0 +1,907 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/blockysource/go-pkg/times/tznames"
+)
+
+// DateFormat is a compiled and localized date format.
+// It is created either from a strftime format (see NewStrftime),
+// or from a CLDR date pattern (see NewDatePattern).
+// A DateFormat is safe for concurrent use.
+type DateFormat struct {
+	tokens []dateToken
+	locale *DateLocale
+	hasEra bool
+}
+
+// NewStrftime compiles the strftime format in given locale, i.e. "%A, %d. %B %Y".
+// The supported conversions are:
+//
+//	%a %A  abbreviated and full weekday name
+//	%b %h  abbreviated month name
+//	%B     full month name
+//	%c     date and time, same as "%a %b %e %H:%M:%S %Y"
+//	%d %e  day of the month, zero and space padded
+//	%D %x  same as "%m/%d/%y"
+//	%f     microseconds, 6 digits
+//	%F     same as "%Y-%m-%d"
+//	%H %I  hour (00-23) and hour (01-12)
+//	%j     day of the year (001-366)
+//	%m %M  month (01-12) and minute (00-59)
+//	%p     AM or PM
+//	%R     same as "%H:%M"
+//	%s     seconds since the Unix epoch
+//	%S     second (00-60)
+//	%T %X  same as "%H:%M:%S"
+//	%u %w  weekday number, Monday as 1 and Sunday as 0
+//	%y %Y  two-digit and four-digit year
+//	%z     UTC offset, i.e. "+0100"
+//	%Z     zone abbreviation, i.e. "CET"
+//	%n %t  newline and tab
+//	%%     literal percent sign
+//
+// The "-" flag, i.e. "%-d", removes the padding of numeric conversions.
+func NewStrftime(format, locale string) (*DateFormat, error) {
+	l, ok := LookupDateLocale(locale)
+	if !ok {
+		return nil, fmt.Errorf("unknown date locale %q", locale)
+	}
+	tokens, err := compileStrftime(format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid strftime format %q: %w", format, err)
+	}
+	return &DateFormat{tokens: tokens, locale: l}, nil
+}
+
+// NewDatePattern compiles the CLDR (ICU) date pattern in given locale, i.e. "EEEE, d. MMMM yyyy".
+// The supported pattern fields are:
+//
+//	G      era, i.e. "AD"
+//	y      year, "yy" is the two-digit year, with the era the year of the era, i.e. "BC 1" for the year 0
+//	M L    month, "M" and "MM" numeric, "MMM" abbreviated and "MMMM" full name
+//	d D    day of the month and day of the year
+//	E      weekday, "E" to "EEE" abbreviated and "EEEE" full name
+//	a      AM or PM
+//	H h    hour (0-23) and hour (1-12)
+//	m s    minute and second
+//	S      fraction of the second, i.e. "SSS" for milliseconds
+//	z      zone name, "z" to "zzz" short and "zzzz" long, i.e. "CEST" and "Central European Summer Time"
+//	Z      UTC offset, "Z" to "ZZZ" as "+0100" and "ZZZZZ" as "+01:00" or "Z"
+//	O      localized GMT offset, "O" as "GMT+1" and "OOOO" as "GMT+01:00"
+//	X x    ISO 8601 UTC offset, "X" as "+01", "XX" as "+0100" and "XXX" as "+01:00",
+//	       where "X" formats the zero offset as "Z"
+//	VV     zone identifier, i.e. "Europe/Berlin"
+//
+// Text in single quotes is a literal, and two single quotes are a literal quote.
+// The zone names are localized with the tznames package.
+func NewDatePattern(pattern, locale string) (*DateFormat, error) {
+	l, ok := LookupDateLocale(locale)
+	if !ok {
+		return nil, fmt.Errorf("unknown date locale %q", locale)
+	}
+	tokens, err := compileDatePattern(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date pattern %q: %w", pattern, err)
+	}
+	f := &DateFormat{tokens: tokens, locale: l}
+	for _, tok := range tokens {
+		f.hasEra = f.hasEra || tok.field == fieldEra
+	}
+	return f, nil
+}
+
+// Strftime formats t with the strftime format in given locale.
+func Strftime(t time.Time, format, locale string) (string, error) {
+	f, err := NewStrftime(format, locale)
+	if err != nil {
+		return "", err
+	}
+	return f.Format(t), nil
+}
+
+// FormatDatePattern formats t with the CLDR date pattern in given locale.
+func FormatDatePattern(t time.Time, pattern, locale string) (string, error) {
+	f, err := NewDatePattern(pattern, locale)
+	if err != nil {
+		return "", err
+	}
+	return f.Format(t), nil
+}
+
+// dateField is the kind of the date format token.
+type dateField int
+
+const (
+	fieldLiteral dateField = iota
+	fieldEra
+	fieldYear
+	fieldMonth
+	fieldMonthName
+	fieldDay
+	fieldDayOfYear
+	fieldWeekday
+	fieldWeekdayNumber
+	fieldHour
+	fieldHour12
+	fieldMinute
+	fieldSecond
+	fieldFraction
+	fieldDayPeriod
+	fieldZoneName
+	fieldZoneAbbreviation
+	fieldZoneID
+	fieldOffset
+	fieldGMTOffset
+	fieldUnix
+)
+
+type dateToken struct {
+	field  dateField
+	text   string // The literal text.
+	width  int    // The minimal number of digits, or the number of fraction digits.
+	pad    byte   // The padding of numbers, '0' or ' '.
+	long   bool   // Full names, instead of abbreviated ones.
+	twoDig bool   // Two-digit year.
+	sunday bool   // Weekday number from 0 on Sunday, instead of 1 on Monday.
+	colon  bool   // Offset with the colon separator.
+	zulu   bool   // Zero offset formatted as "Z".
+	hours  bool   // Offset minutes are formatted only if not zero.
+}
+
+func (t dateToken) numeric() bool {
+	switch t.field {
+	case fieldYear, fieldMonth, fieldDay, fieldDayOfYear, fieldWeekdayNumber,
+		fieldHour, fieldHour12, fieldMinute, fieldSecond, fieldFraction, fieldUnix:
+		return true
+	}
+	return false
+}
+
+func compileStrftime(format string) ([]dateToken, error) {
+	var tokens []dateToken
+	literal := func(s string) {
+		if n := len(tokens); n > 0 && tokens[n-1].field == fieldLiteral {
+			tokens[n-1].text += s
+			return
+		}
+		tokens = append(tokens, dateToken{field: fieldLiteral, text: s})
+	}
+	num := func(field dateField, width int) dateToken {
+		return dateToken{field: field, width: width, pad: '0'}
+	}
+
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		if c != '%' {
+			literal(format[i : i+1])
+			continue
+		}
+		i++
+		noPad := i < len(format) && format[i] == '-'
+		if noPad {
+			i++
+		}
+		if i == len(format) {
+			return nil, errors.New("trailing %")
+		}
+
+		var add []dateToken
+		switch format[i] {
+		case 'a', 'A':
+			add = []dateToken{{field: fieldWeekday, long: format[i] == 'A'}}
+		case 'b', 'h', 'B':
+			add = []dateToken{{field: fieldMonthName, long: format[i] == 'B'}}
+		case 'c':
+			sub, _ := compileStrftime("%a %b %e %H:%M:%S %Y")
+			add = sub
+		case 'd':
+			add = []dateToken{num(fieldDay, 2)}
+		case 'e':
+			add = []dateToken{{field: fieldDay, width: 2, pad: ' '}}
+		case 'D', 'x':
+			sub, _ := compileStrftime("%m/%d/%y")
+			add = sub
+		case 'f':
+			add = []dateToken{{field: fieldFraction, width: 6}}
+		case 'F':
+			sub, _ := compileStrftime("%Y-%m-%d")
+			add = sub
+		case 'H':
+			add = []dateToken{num(fieldHour, 2)}
+		case 'I':
+			add = []dateToken{num(fieldHour12, 2)}
+		case 'j':
+			add = []dateToken{num(fieldDayOfYear, 3)}
+		case 'm':
+			add = []dateToken{num(fieldMonth, 2)}
+		case 'M':
+			add = []dateToken{num(fieldMinute, 2)}
+		case 'p':
+			add = []dateToken{{field: fieldDayPeriod}}
+		case 'R':
+			sub, _ := compileStrftime("%H:%M")
+			add = sub
+		case 's':
+			add = []dateToken{{field: fieldUnix}}
+		case 'S':
+			add = []dateToken{num(fieldSecond, 2)}
+		case 'T', 'X':
+			sub, _ := compileStrftime("%H:%M:%S")
+			add = sub
+		case 'u', 'w':
+			add = []dateToken{{field: fieldWeekdayNumber, width: 1, sunday: format[i] == 'w'}}
+		case 'y':
+			add = []dateToken{{field: fieldYear, width: 2, pad: '0', twoDig: true}}
+		case 'Y':
+			add = []dateToken{num(fieldYear, 4)}
+		case 'z':
+			add = []dateToken{{field: fieldOffset}}
+		case 'Z':
+			add = []dateToken{{field: fieldZoneAbbreviation}}
+		case 'n':
+			literal("\n")
+		case 't':
+			literal("\t")
+		case '%':
+			literal("%")
+		default:
+			return nil, fmt.Errorf("unsupported conversion %%%c", format[i])
+		}
+		for _, t := range add {
+			if t.field == fieldLiteral {
+				literal(t.text)
+				continue
+			}
+			if noPad && t.numeric() && t.field != fieldFraction {
+				t.width = 1
+			}
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens, nil
+}
+
+func compileDatePattern(pattern string) ([]dateToken, error) {
+	var tokens []dateToken
+	literal := func(s string) {
+		if n := len(tokens); n > 0 && tokens[n-1].field == fieldLiteral {
+			tokens[n-1].text += s
+			return
+		}
+		tokens = append(tokens, dateToken{field: fieldLiteral, text: s})
+	}
+
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		if c == '\'' {
+			// Quoted literal, or an escaped quote.
+			if i+1 < len(pattern) && pattern[i+1] == '\'' {
+				literal("'")
+				i += 2
+				continue
+			}
+			end := i + 1
+			var text strings.Builder
+			for {
+				if end >= len(pattern) {
+					return nil, errors.New("unterminated quoted literal")
+				}
+				if pattern[end] == '\'' {
+					if end+1 < len(pattern) && pattern[end+1] == '\'' {
+						text.WriteByte('\'')
+						end += 2
+						continue
+					}
+					break
+				}
+				text.WriteByte(pattern[end])
+				end++
+			}
+			literal(text.String())
+			i = end + 1
+			continue
+		}
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			literal(pattern[i : i+1])
+			i++
+			continue
+		}
+
+		n := 1
+		for i+n < len(pattern) && pattern[i+n] == c {
+			n++
+		}
+		i += n
+
+		var t dateToken
+		switch {
+		case c == 'G' && n <= 4:
+			t = dateToken{field: fieldEra}
+		case c == 'y':
+			t = dateToken{field: fieldYear, width: n, pad: '0', twoDig: n == 2}
+		case (c == 'M' || c == 'L') && n <= 2:
+			t = dateToken{field: fieldMonth, width: n, pad: '0'}
+		case (c == 'M' || c == 'L') && n <= 4:
+			t = dateToken{field: fieldMonthName, long: n == 4}
+		case c == 'd' && n <= 2:
+			t = dateToken{field: fieldDay, width: n, pad: '0'}
+		case c == 'D' && n <= 3:
+			t = dateToken{field: fieldDayOfYear, width: n, pad: '0'}
+		case c == 'E' && n <= 4:
+			t = dateToken{field: fieldWeekday, long: n == 4}
+		case c == 'a' && n <= 3:
+			t = dateToken{field: fieldDayPeriod}
+		case c == 'H' && n <= 2:
+			t = dateToken{field: fieldHour, width: n, pad: '0'}
+		case c == 'h' && n <= 2:
+			t = dateToken{field: fieldHour12, width: n, pad: '0'}
+		case c == 'm' && n <= 2:
+			t = dateToken{field: fieldMinute, width: n, pad: '0'}
+		case c == 's' && n <= 2:
+			t = dateToken{field: fieldSecond, width: n, pad: '0'}
+		case c == 'S' && n <= 9:
+			t = dateToken{field: fieldFraction, width: n}
+		case c == 'z' && n <= 4:
+			t = dateToken{field: fieldZoneName, long: n == 4}
+		case c == 'Z' && n <= 3:
+			t = dateToken{field: fieldOffset}
+		case c == 'Z' && n == 4:
+			t = dateToken{field: fieldGMTOffset, long: true}
+		case c == 'Z' && n == 5:
+			t = dateToken{field: fieldOffset, colon: true, zulu: true}
+		case c == 'O' && (n == 1 || n == 4):
+			t = dateToken{field: fieldGMTOffset, long: n == 4}
+		case (c == 'X' || c == 'x') && n <= 3:
+			t = dateToken{field: fieldOffset, colon: n == 3, hours: n == 1, zulu: c == 'X'}
+		case c == 'V' && n == 2:
+			t = dateToken{field: fieldZoneID}
+		default:
+			return nil, fmt.Errorf("unsupported pattern field %q", strings.Repeat(string(c), n))
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, nil
+}
+
+// Format formats t in its location.
+func (f *DateFormat) Format(t time.Time) string {
+	var b []byte
+	for _, tok := range f.tokens {
+		b = f.appendToken(b, tok, t)
+	}
+	return string(b)
+}
+
+func (f *DateFormat) appendToken(b []byte, tok dateToken, t time.Time) []byte {
+	switch tok.field {
+	case fieldLiteral:
+		return append(b, tok.text...)
+	case fieldEra:
+		if t.Year() <= 0 {
+			return append(b, f.locale.Eras[0]...)
+		}
+		return append(b, f.locale.Eras[1]...)
+	case fieldYear:
+		year := t.Year()
+		if f.hasEra && year <= 0 {
+			// The era year counts back from 1 BC, there is no year 0.
+			year = 1 - year
+		}
+		if tok.twoDig {
+			year %= 100
+			if year < 0 {
+				year = -year
+			}
+		}
+		return appendPadded(b, year, tok.width, tok.pad)
+	case fieldMonth:
+		return appendPadded(b, int(t.Month()), tok.width, tok.pad)
+	case fieldMonthName:
+		if tok.long {
+			return append(b, f.locale.Months[t.Month()-1]...)
+		}
+		return append(b, f.locale.ShortMonths[t.Month()-1]...)
+	case fieldDay:
+		return appendPadded(b, t.Day(), tok.width, tok.pad)
+	case fieldDayOfYear:
+		return appendPadded(b, t.YearDay(), tok.width, tok.pad)
+	case fieldWeekday:
+		if tok.long {
+			return append(b, f.locale.Weekdays[t.Weekday()]...)
+		}
+		return append(b, f.locale.ShortWeekdays[t.Weekday()]...)
+	case fieldWeekdayNumber:
+		wd := int(t.Weekday())
+		if !tok.sunday && wd == 0 {
+			wd = 7
+		}
+		return appendPadded(b, wd, 1, '0')
+	case fieldHour:
+		return appendPadded(b, t.Hour(), tok.width, tok.pad)
+	case fieldHour12:
+		h := t.Hour() % 12
+		if h == 0 {
+			h = 12
+		}
+		return appendPadded(b, h, tok.width, tok.pad)
+	case fieldMinute:
+		return appendPadded(b, t.Minute(), tok.width, tok.pad)
+	case fieldSecond:
+		return appendPadded(b, t.Second(), tok.width, tok.pad)
+	case fieldFraction:
+		frac := t.Nanosecond()
+		for i := tok.width; i < 9; i++ {
+			frac /= 10
+		}
+		return appendPadded(b, frac, tok.width, '0')
+	case fieldDayPeriod:
+		if t.Hour() < 12 {
+			return append(b, f.locale.DayPeriods[0]...)
+		}
+		return append(b, f.locale.DayPeriods[1]...)
+	case fieldZoneName:
+		width := tznames.Short
+		if tok.long {
+			width = tznames.Long
+		}
+		return append(b, tznames.Name(f.locale.Tag, t.Location(), t, width)...)
+	case fieldZoneAbbreviation:
+		name, _ := t.Zone()
+		return append(b, name...)
+	case fieldZoneID:
+		return append(b, t.Location().String()...)
+	case fieldOffset:
+		_, offset := t.Zone()
+		return appendOffset(b, offset, tok)
+	case fieldGMTOffset:
+		_, offset := t.Zone()
+		b = append(b, "GMT"...)
+		if offset == 0 {
+			return b
+		}
+		if tok.long {
+			return appendOffset(b, offset, dateToken{colon: true})
+		}
+		return appendOffset(b, offset, dateToken{colon: true, hours: true, width: 1})
+	case fieldUnix:
+		return strconv.AppendInt(b, t.Unix(), 10)
+	}
+	return b
+}
+
+func appendPadded(b []byte, v, width int, pad byte) []byte {
+	if v < 0 {
+		b = append(b, '-')
+		v = -v
+	}
+	s := strconv.Itoa(v)
+	for i := len(s); i < width; i++ {
+		b = append(b, pad)
+	}
+	return append(b, s...)
+}
+
+// appendOffset appends the UTC offset, i.e. "+0100", "+01:00", "+01" or "Z".
+// The width of 1 formats the hours without padding, which is used by the GMT format.
+func appendOffset(b []byte, offset int, tok dateToken) []byte {
+	if offset == 0 && tok.zulu {
+		return append(b, 'Z')
+	}
+	sign := byte('+')
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	hours, minutes := offset/3600, offset%3600/60
+	b = append(b, sign)
+	width := 2
+	if tok.width == 1 {
+		width = 1
+	}
+	b = appendPadded(b, hours, width, '0')
+	if tok.hours && minutes == 0 {
+		return b
+	}
+	if tok.colon {
+		b = append(b, ':')
+	}
+	return appendPadded(b, minutes, 2, '0')
+}
+
+// DateParseError describes a problem parsing the date value.
+type DateParseError struct {
+	Value   string // The parsed value.
+	Offset  int    // The byte offset in the value, where the problem occurred.
+	Message string // The description of the problem.
+}
+
+func (e *DateParseError) Error() string {
+	return fmt.Sprintf("parsing date %q at offset %d: %s", e.Value, e.Offset, e.Message)
+}
+
+// dateFields are the fields collected while parsing.
+type dateFields struct {
+	year, month, day, yday    int
+	hour, minute, sec, nsec   int
+	weekday                   int
+	hasWeekday, hasYday       bool
+	bc, pm, hasPeriod, hasH12 bool
+	unix                      *int64
+	loc                       *time.Location
+	kind                      tznames.Kind
+	hasKind                   bool
+}
+
+// Parse parses the date value formatted with f.
+// The value is interpreted in the location given by its zone or offset fields.
+// If the value has no such fields, the location loc is used, or UTC if loc is nil.
+// Missing fields default to their zero values, like in time.Parse.
+// Two-digit years are in the range 1969-2068.
+// Zone names are resolved with the tznames package and LookupZone,
+// and the standard or daylight name selects the offset of an ambiguous local time.
+func (f *DateFormat) Parse(value string, loc *time.Location) (time.Time, error) {
+	d := dateFields{month: 1, day: 1}
+	s := value
+	fail := func(msg string) (time.Time, error) {
+		return time.Time{}, &DateParseError{Value: value, Offset: len(value) - len(s), Message: msg}
+	}
+
+	for i, tok := range f.tokens {
+		var next *dateToken
+		if i+1 < len(f.tokens) {
+			next = &f.tokens[i+1]
+		}
+		// Adjacent numeric fields have fixed widths, i.e. "yyyyMMdd".
+		fixed := next != nil && next.numeric() && tok.numeric()
+
+		switch tok.field {
+		case fieldLiteral:
+			if !strings.HasPrefix(s, tok.text) {
+				return fail(fmt.Sprintf("expected %q", tok.text))
+			}
+			s = s[len(tok.text):]
+		case fieldEra:
+			i, ok := matchName(&s, f.locale.Eras[:])
+			if !ok {
+				return fail("expected era")
+			}
+			d.bc = i == 0
+		case fieldYear:
+			maxDigits := 9
+			if tok.twoDig || fixed {
+				maxDigits = tok.width
+				if maxDigits < 4 && !tok.twoDig {
+					maxDigits = 4
+				}
+			}
+			v, ok := parseNumber(&s, maxDigits, tok.pad)
+			if !ok {
+				return fail("expected year")
+			}
+			if tok.twoDig {
+				if v < 69 {
+					v += 2000
+				} else {
+					v += 1900
+				}
+			}
+			d.year = v
+		case fieldMonth, fieldDay, fieldHour, fieldHour12, fieldMinute, fieldSecond, fieldWeekdayNumber, fieldDayOfYear:
+			maxDigits := 2
+			if tok.field == fieldDayOfYear {
+				maxDigits = 3
+			} else if tok.field == fieldWeekdayNumber {
+				maxDigits = 1
+			}
+			if fixed && tok.width > 1 {
+				maxDigits = tok.width
+			}
+			v, ok := parseNumber(&s, maxDigits, tok.pad)
+			if !ok {
+				return fail("expected number")
+			}
+			switch tok.field {
+			case fieldMonth:
+				d.month = v
+			case fieldDay:
+				d.day = v
+			case fieldDayOfYear:
+				d.yday, d.hasYday = v, true
+			case fieldHour:
+				d.hour = v
+			case fieldHour12:
+				d.hour, d.hasH12 = v, true
+			case fieldMinute:
+				d.minute = v
+			case fieldSecond:
+				d.sec = v
+			case fieldWeekdayNumber:
+				d.weekday, d.hasWeekday = v%7, true
+			}
+		case fieldMonthName:
+			names := f.locale.ShortMonths[:]
+			if tok.long {
+				names = f.locale.Months[:]
+			}
+			i, ok := matchName(&s, names)
+			if !ok {
+				return fail("expected month name")
+			}
+			d.month = i + 1
+		case fieldWeekday:
+			names := f.locale.ShortWeekdays[:]
+			if tok.long {
+				names = f.locale.Weekdays[:]
+			}
+			i, ok := matchName(&s, names)
+			if !ok {
+				return fail("expected weekday name")
+			}
+			d.weekday, d.hasWeekday = i, true
+		case fieldFraction:
+			start := s
+			maxDigits := 9
+			if fixed {
+				maxDigits = tok.width
+			}
+			v, ok := parseNumber(&s, maxDigits, 0)
+			if !ok {
+				return fail("expected fraction of second")
+			}
+			for n := len(start) - len(s); n < 9; n++ {
+				v *= 10
+			}
+			d.nsec = v
+		case fieldDayPeriod:
+			i, ok := matchName(&s, f.locale.DayPeriods[:])
+			if !ok {
+				return fail("expected day period")
+			}
+			d.pm, d.hasPeriod = i == 1, true
+		case fieldUnix:
+			neg := strings.HasPrefix(s, "-")
+			if neg {
+				s = s[1:]
+			}
+			v, ok := parseNumber(&s, 19, 0)
+			if !ok {
+				return fail("expected unix seconds")
+			}
+			sec := int64(v)
+			if neg {
+				sec = -sec
+			}
+			d.unix = &sec
+		case fieldOffset, fieldGMTOffset:
+			var prefix int
+			if tok.field == fieldGMTOffset {
+				if !strings.HasPrefix(s, "GMT") {
+					return fail(`expected "GMT"`)
+				}
+				if len(s) == 3 || (s[3] != '+' && s[3] != '-') {
+					s = s[3:]
+					d.loc = time.UTC
+					continue
+				}
+				// The GMT prefix allows the unpadded hours, i.e. "GMT+2".
+				prefix = 3
+			}
+			n := prefix + offsetLength(s[prefix:])
+			if n == prefix {
+				return fail("expected UTC offset")
+			}
+			zone, err := ParseOffsetZone(s[:n])
+			if err != nil {
+				return fail(err.Error())
+			}
+			s = s[n:]
+			d.loc = zone
+		case fieldZoneName, fieldZoneAbbreviation, fieldZoneID:
+			text := zoneText(s, next)
+			if text == "" {
+				return fail("expected time zone")
+			}
+			zone, kind, hasKind, ok := f.resolveZone(text, tok.field)
+			if !ok {
+				return fail(fmt.Sprintf("unknown time zone %q", text))
+			}
+			s = s[len(text):]
+			d.loc, d.kind, d.hasKind = zone, kind, hasKind
+		}
+	}
+	if s != "" {
+		return fail(fmt.Sprintf("unexpected %q", s))
+	}
+	return d.time(loc, func(msg string) error {
+		return &DateParseError{Value: value, Offset: len(value), Message: msg}
+	})
+}
+
+func (d *dateFields) time(loc *time.Location, fail func(string) error) (time.Time, error) {
+	if d.loc != nil {
+		loc = d.loc
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	if d.unix != nil {
+		return time.Unix(*d.unix, int64(d.nsec)).In(loc), nil
+	}
+
+	if d.bc {
+		d.year = 1 - d.year
+	}
+	if d.hasH12 || d.hasPeriod {
+		if d.hour > 12 || (d.hasH12 && d.hour == 0) {
+			return time.Time{}, fail("hour out of range")
+		}
+		if d.pm && d.hour < 12 {
+			d.hour += 12
+		} else if !d.pm && d.hour == 12 {
+			d.hour = 0
+		}
+	}
+	if d.hasYday {
+		first := time.Date(d.year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		if d.yday < 1 || d.yday > first.AddDate(1, 0, -1).YearDay() {
+			return time.Time{}, fail("day of year out of range")
+		}
+		date := first.AddDate(0, 0, d.yday-1)
+		d.month, d.day = int(date.Month()), date.Day()
+	}
+	switch {
+	case d.month < 1 || d.month > 12:
+		return time.Time{}, fail("month out of range")
+	case d.day < 1 || d.day > daysIn(time.Month(d.month), d.year):
+		return time.Time{}, fail("day out of range")
+	case d.hour > 23:
+		return time.Time{}, fail("hour out of range")
+	case d.minute > 59:
+		return time.Time{}, fail("minute out of range")
+	case d.sec > 60:
+		return time.Time{}, fail("second out of range")
+	}
+
+	t := time.Date(d.year, time.Month(d.month), d.day, d.hour, d.minute, d.sec, d.nsec, loc)
+	if d.hasKind {
+		// The same local time may occur twice, when the clocks are turned back.
+		// The zone name tells which one is meant.
+		switch {
+		case d.kind == tznames.Daylight && !t.IsDST():
+			if alt := t.Add(-time.Hour); alt.IsDST() && sameClock(alt, t) {
+				t = alt
+			}
+		case d.kind == tznames.Standard && t.IsDST():
+			if alt := t.Add(time.Hour); !alt.IsDST() && sameClock(alt, t) {
+				t = alt
+			}
+		}
+	}
+	if d.hasWeekday && int(t.Weekday()) != d.weekday {
+		return time.Time{}, fail(fmt.Sprintf("weekday does not match the date, it is %s", t.Weekday()))
+	}
+	return t, nil
+}
+
+func sameClock(a, b time.Time) bool {
+	return a.Hour() == b.Hour() && a.Minute() == b.Minute() && a.Day() == b.Day()
+}
+
+func daysIn(m time.Month, year int) int {
+	return time.Date(year, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// resolveZone resolves the zone name, abbreviation or identifier.
+func (f *DateFormat) resolveZone(text string, field dateField) (loc *time.Location, kind tznames.Kind, hasKind bool, ok bool) {
+	if field == fieldZoneID {
+		loc, err := LoadLocation(text)
+		return loc, 0, false, err == nil && text != "Local"
+	}
+	if zone, kind, ok := tznames.Find(f.locale.Tag, text); ok {
+		if loc, err := LoadLocation(zone); err == nil {
+			return loc, kind, kind != tznames.Generic, true
+		}
+	}
+	if strings.HasPrefix(text, "GMT") || strings.HasPrefix(text, "UTC") {
+		if loc, err := ParseOffsetZone(text); err == nil {
+			return loc, 0, false, true
+		}
+	}
+	candidates, err := LookupZone(text)
+	if err != nil {
+		return nil, 0, false, false
+	}
+	return candidates[0].Location, 0, false, true
+}
+
+// zoneText returns the text of the zone field, which spans up to the next literal.
+func zoneText(s string, next *dateToken) string {
+	if next != nil && next.field == fieldLiteral {
+		if i := strings.Index(s, next.text); i >= 0 {
+			return s[:i]
+		}
+		return s
+	}
+	if next == nil {
+		return s
+	}
+	// The zone is followed by a field, thus it must end with the first space.
+	if i := strings.IndexByte(s, ' '); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// offsetLength returns the length of the offset prefix of s, i.e. "Z", "+01", "+0100" or "+01:00".
+func offsetLength(s string) int {
+	if strings.HasPrefix(s, "Z") {
+		return 1
+	}
+	if s == "" || (s[0] != '+' && s[0] != '-') {
+		return 0
+	}
+	n := 1
+	for n < len(s) && n < 6 && (s[n] >= '0' && s[n] <= '9' || s[n] == ':') {
+		n++
+	}
+	return n
+}
+
+// parseNumber parses at most maxDigits leading digits of s, skipping leading padding.
+func parseNumber(s *string, maxDigits int, pad byte) (int, bool) {
+	if pad == ' ' {
+		*s = strings.TrimLeft(*s, " ")
+	}
+	n := 0
+	for n < len(*s) && n < maxDigits && (*s)[n] >= '0' && (*s)[n] <= '9' {
+		n++
+	}
+	v, ok := parseDigits((*s)[:n])
+	if !ok {
+		return 0, false
+	}
+	*s = (*s)[n:]
+	return v, true
+}
+
+// matchName consumes the longest name of the names matching the prefix of s, ignoring the case.
+func matchName(s *string, names []string) (int, bool) {
+	best, bestLen := -1, 0
+	for i, name := range names {
+		// Compare the prefix with the same number of runes as the name.
+		var k int
+		for j := utf8.RuneCountInString(name); j > 0 && k < len(*s); j-- {
+			_, size := utf8.DecodeRuneInString((*s)[k:])
+			k += size
+		}
+		if k > bestLen && strings.EqualFold((*s)[:k], name) {
+			best, bestLen = i, k
+		}
+	}
+	if best < 0 {
+		return 0, false
+	}
+	*s = (*s)[bestLen:]
+	return best, true
+}
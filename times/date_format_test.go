@@ -0,0 +1,194 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateFormatFormat(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(2023, time.July, 2, 15, 4, 5, 123456789, berlin)
+
+	for _, test := range []struct {
+		strftime bool
+		layout   string
+		locale   string
+		want     string
+	}{
+		{strftime: true, layout: "%Y-%m-%d %H:%M:%S %z %Z", locale: "en", want: "2023-07-02 15:04:05 +0200 CEST"},
+		{strftime: true, layout: "%A, %-d. %B %Y", locale: "de", want: "Sonntag, 2. Juli 2023"},
+		{strftime: true, layout: "%a %b %e %I:%M %p, day %j, %u/%w", locale: "en", want: "Sun Jul  2 03:04 PM, day 183, 7/0"},
+		{strftime: true, layout: "%F %T.%f", locale: "fr", want: "2023-07-02 15:04:05.123456"},
+		{layout: "yyyy-MM-dd'T'HH:mm:ss.SSSXXX", locale: "en", want: "2023-07-02T15:04:05.123+02:00"},
+		{layout: "EEEE, d. MMMM y G", locale: "de", want: "Sonntag, 2. Juli 2023 n. Chr."},
+		{layout: "EEE d MMM yy, h:mm a", locale: "es", want: "dom 2 jul 23, 3:04 p. m."},
+		{layout: "zzzz", locale: "de", want: "Mitteleuropäische Sommerzeit"},
+		{layout: "z '('VV', 'O')'", locale: "en", want: "GMT+2 (Europe/Berlin, GMT+2)"},
+		{layout: "ZZZZZ x", locale: "en", want: "+02:00 +02"},
+		{layout: "''HH'' 'o''clock'", locale: "en", want: "'15' o'clock"},
+	} {
+		t.Run(test.layout, func(t *testing.T) {
+			var f *DateFormat
+			var err error
+			if test.strftime {
+				f, err = NewStrftime(test.layout, test.locale)
+			} else {
+				f, err = NewDatePattern(test.layout, test.locale)
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := f.Format(ts); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDateFormatParse(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		strftime bool
+		layout   string
+		locale   string
+		value    string
+		want     time.Time
+	}{
+		{strftime: true, layout: "%d.%m.%Y %H:%M", locale: "de", value: "02.07.2023 15:04",
+			want: time.Date(2023, time.July, 2, 15, 4, 0, 0, berlin)},
+		{strftime: true, layout: "%A, %-d. %B %Y", locale: "de", value: "sonntag, 2. Juli 2023",
+			want: time.Date(2023, time.July, 2, 0, 0, 0, 0, berlin)},
+		{strftime: true, layout: "%b %e %I:%M %p %z", locale: "en", value: "Jul  2 03:04 PM -0700",
+			want: time.Date(0, time.July, 2, 22, 4, 0, 0, time.UTC)},
+		{strftime: true, layout: "%s", locale: "en", value: "1688303045",
+			want: time.Unix(1688303045, 0)},
+		{layout: "yyyyMMddHHmmss", locale: "en", value: "20230702150405",
+			want: time.Date(2023, time.July, 2, 15, 4, 5, 0, berlin)},
+		{layout: "yyyy-MM-dd'T'HH:mm:ss.SSSXXX", locale: "en", value: "2023-07-02T13:04:05.123Z",
+			want: time.Date(2023, time.July, 2, 13, 4, 5, 123000000, time.UTC)},
+		{layout: "d MMMM yy HH:mm zzzz", locale: "de", value: "2 Juli 23 15:04 Mitteleuropäische Sommerzeit",
+			want: time.Date(2023, time.July, 2, 13, 4, 0, 0, time.UTC)},
+		{layout: "yyyy-MM-dd HH:mm zzzz", locale: "en", value: "2023-10-29 02:30 Central European Standard Time",
+			want: time.Date(2023, time.October, 29, 1, 30, 0, 0, time.UTC)},
+		{layout: "yyyy-MM-dd HH:mm zzzz", locale: "en", value: "2023-10-29 02:30 Central European Summer Time",
+			want: time.Date(2023, time.October, 29, 0, 30, 0, 0, time.UTC)},
+		{layout: "yyyy-MM-dd HH:mm z", locale: "en", value: "2023-01-02 10:00 PST",
+			want: time.Date(2023, time.January, 2, 18, 0, 0, 0, time.UTC)},
+		{layout: "yyyy-MM-dd HH:mm VV", locale: "en", value: "2023-01-02 10:00 Asia/Tokyo",
+			want: time.Date(2023, time.January, 2, 1, 0, 0, 0, time.UTC)},
+		{layout: "D/yyyy", locale: "en", value: "183/2023",
+			want: time.Date(2023, time.July, 2, 0, 0, 0, 0, berlin)},
+		{layout: "yyyy-MM-dd HH:mm O", locale: "en", value: "2023-07-02 15:04 GMT+2",
+			want: time.Date(2023, time.July, 2, 13, 4, 0, 0, time.UTC)},
+		{layout: "yyyy-MM-dd HH:mm O", locale: "en", value: "2023-07-02 15:04 GMT-9:30",
+			want: time.Date(2023, time.July, 3, 0, 34, 0, 0, time.UTC)},
+		{layout: "G y-MM-dd", locale: "en", value: "BC 44-03-15",
+			want: time.Date(-43, time.March, 15, 0, 0, 0, 0, berlin)},
+	} {
+		t.Run(test.value, func(t *testing.T) {
+			var f *DateFormat
+			var err error
+			if test.strftime {
+				f, err = NewStrftime(test.layout, test.locale)
+			} else {
+				f, err = NewDatePattern(test.layout, test.locale)
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := f.Parse(test.value, berlin)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDateFormatRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		layout string
+		t      time.Time
+		want   string
+	}{
+		{layout: "G y-MM-dd", t: time.Date(0, time.March, 15, 0, 0, 0, 0, time.UTC), want: "BC 1-03-15"},
+		{layout: "G y-MM-dd", t: time.Date(-43, time.March, 15, 0, 0, 0, 0, time.UTC), want: "BC 44-03-15"},
+		{layout: "G y-MM-dd", t: time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC), want: "AD 1-01-01"},
+		{layout: "yyyy-MM-dd HH:mm O", t: time.Date(2023, time.July, 2, 15, 4, 0, 0, time.FixedZone("", 2*3600)), want: "2023-07-02 15:04 GMT+2"},
+		{layout: "yyyy-MM-dd HH:mm O", t: time.Date(2023, time.July, 2, 15, 4, 0, 0, time.FixedZone("", -(9*3600+1800))), want: "2023-07-02 15:04 GMT-9:30"},
+	} {
+		t.Run(test.want, func(t *testing.T) {
+			f, err := NewDatePattern(test.layout, "en")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			s := f.Format(test.t)
+			if s != test.want {
+				t.Errorf("got %q, want %q", s, test.want)
+			}
+			got, err := f.Parse(s, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(test.t) {
+				t.Errorf("got %v, want %v", got, test.t)
+			}
+		})
+	}
+}
+
+func TestDateFormatParseErrors(t *testing.T) {
+	for _, test := range []struct {
+		pattern string
+		value   string
+	}{
+		{pattern: "yyyy-MM-dd", value: "2023-13-01"},
+		{pattern: "yyyy-MM-dd", value: "2023-02-29"},
+		{pattern: "yyyy-MM-dd", value: "2023-02-01 extra"},
+		{pattern: "EEEE, yyyy-MM-dd", value: "Monday, 2023-07-02"},
+		{pattern: "yyyy-MM-dd VV", value: "2023-07-02 Mars/Olympus"},
+		{pattern: "h:mm a", value: "13:00 PM"},
+	} {
+		f, err := NewDatePattern(test.pattern, "en")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.pattern, err)
+		}
+		if got, err := f.Parse(test.value, nil); err == nil {
+			t.Errorf("%q: got %v, want error", test.value, got)
+		}
+	}
+
+	for _, pattern := range []string{"yyyy 'unterminated", "QQQ", "MMMMM"} {
+		if _, err := NewDatePattern(pattern, "en"); err == nil {
+			t.Errorf("%q: got nil error", pattern)
+		}
+	}
+	if _, err := NewStrftime("%Q", "en"); err == nil {
+		t.Errorf("%%Q: got nil error")
+	}
+	if _, err := NewStrftime("%Y", "xx"); err == nil {
+		t.Errorf("unknown locale: got nil error")
+	}
+}
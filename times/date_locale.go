@@ -0,0 +1,107 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"strings"
+	"sync"
+)
+
+// DateLocale contains the localized names used to format and parse dates.
+// The names are the CLDR "format" context names of the Gregorian calendar.
+type DateLocale struct {
+	// Tag is the BCP 47 language tag of the locale, i.e. "de".
+	Tag string
+	// Months are the month names, starting with January.
+	Months [12]string
+	// ShortMonths are the abbreviated month names, starting with January.
+	ShortMonths [12]string
+	// Weekdays are the weekday names, starting with Sunday.
+	Weekdays [7]string
+	// ShortWeekdays are the abbreviated weekday names, starting with Sunday.
+	ShortWeekdays [7]string
+	// Eras are the abbreviated era names, before and after Christ.
+	Eras [2]string
+	// DayPeriods are the AM and PM markers.
+	DayPeriods [2]string
+}
+
+var dateLocales = struct {
+	sync.RWMutex
+	m map[string]*DateLocale
+}{m: map[string]*DateLocale{
+	"en": {
+		Tag:           "en",
+		Months:        [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		ShortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		ShortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		Eras:          [2]string{"BC", "AD"},
+		DayPeriods:    [2]string{"AM", "PM"},
+	},
+	"de": {
+		Tag:           "de",
+		Months:        [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		ShortMonths:   [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		Weekdays:      [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		ShortWeekdays: [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+		Eras:          [2]string{"v. Chr.", "n. Chr."},
+		DayPeriods:    [2]string{"AM", "PM"},
+	},
+	"fr": {
+		Tag:           "fr",
+		Months:        [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		ShortMonths:   [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		Weekdays:      [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		ShortWeekdays: [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		Eras:          [2]string{"av. J.-C.", "ap. J.-C."},
+		DayPeriods:    [2]string{"AM", "PM"},
+	},
+	"es": {
+		Tag:           "es",
+		Months:        [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		ShortMonths:   [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		Weekdays:      [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		ShortWeekdays: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		Eras:          [2]string{"a. C.", "d. C."},
+		DayPeriods:    [2]string{"a. m.", "p. m."},
+	},
+}}
+
+// RegisterDateLocale registers the date locale, replacing the one with the same tag.
+// The embedded locales are "en", "de", "fr" and "es".
+func RegisterDateLocale(l *DateLocale) {
+	dateLocales.Lock()
+	defer dateLocales.Unlock()
+	dateLocales.m[strings.ToLower(l.Tag)] = l
+}
+
+// LookupDateLocale returns the date locale of given language tag, i.e. "de" or "de-AT".
+// If the region specific locale is not registered, its language is used instead.
+func LookupDateLocale(tag string) (*DateLocale, bool) {
+	dateLocales.RLock()
+	defer dateLocales.RUnlock()
+	tag = strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	for {
+		if l, ok := dateLocales.m[tag]; ok {
+			return l, true
+		}
+		i := strings.LastIndexByte(tag, '-')
+		if i < 0 {
+			return nil, false
+		}
+		tag = tag[:i]
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"fmt"
+	"time"
+)
+
+// WeekPattern is the number of weeks of the three periods of a fiscal quarter.
+type WeekPattern [3]int
+
+// The common retail week patterns.
+var (
+	Pattern445 = WeekPattern{4, 4, 5}
+	Pattern454 = WeekPattern{4, 5, 4}
+	Pattern544 = WeekPattern{5, 4, 4}
+)
+
+// YearStartRule defines how the fiscal year start is aligned to the week start day.
+type YearStartRule int
+
+const (
+	// StartNearest starts the year on the week start day nearest to the anchor date.
+	StartNearest YearStartRule = iota
+	// StartOnOrAfter starts the year on the first week start day on or after the anchor date.
+	StartOnOrAfter
+	// StartOnOrBefore starts the year on the last week start day on or before the anchor date.
+	StartOnOrBefore
+)
+
+// FiscalDate is the date in a FiscalCalendar.
+type FiscalDate struct {
+	Year    int // The fiscal year.
+	Quarter int // The quarter of the year, 1 to 4.
+	Period  int // The period (fiscal month) of the year, 1 to 12.
+	Week    int // The week of the year, 1 to 53.
+	Day     int // The day of the year, 1 to 371.
+}
+
+// String returns the date in the form "FY2023-Q1-P02-W05-D031".
+func (d FiscalDate) String() string {
+	return fmt.Sprintf("FY%d-Q%d-P%02d-W%02d-D%03d", d.Year, d.Quarter, d.Period, d.Week, d.Day)
+}
+
+// FiscalCalendar is a week based calendar, which years consist of 52 or 53 whole weeks,
+// grouped in four quarters of three periods following the week pattern, i.e. 4-4-5.
+// Each fiscal year starts on the week start day aligned to the first day of the start month.
+// In the 53 week years, the extra week is added to the leap week period, by default the last one.
+type FiscalCalendar struct {
+	loc            *time.Location
+	pattern        WeekPattern
+	startMonth     time.Month
+	weekStart      time.Weekday
+	rule           YearStartRule
+	endYearNaming  bool
+	leapWeekPeriod int
+}
+
+// NewFiscalCalendar creates a new FiscalCalendar in the location,
+// which years start on the weekStart nearest to the first day of the startMonth.
+func NewFiscalCalendar(loc *time.Location, pattern WeekPattern, startMonth time.Month, weekStart time.Weekday) *FiscalCalendar {
+	return &FiscalCalendar{
+		loc:            loc,
+		pattern:        pattern,
+		startMonth:     startMonth,
+		weekStart:      weekStart,
+		leapWeekPeriod: 12,
+	}
+}
+
+// NewISOWeekCalendar creates a new calendar of the ISO 8601 week dates in the location.
+// The ISO year starts on the Monday nearest to January 1st, the periods follow the 4-4-5 pattern.
+func NewISOWeekCalendar(loc *time.Location) *FiscalCalendar {
+	return NewFiscalCalendar(loc, Pattern445, time.January, time.Monday)
+}
+
+// WithStartRule sets the rule aligning the year start to the week start day,
+// and returns the pointer to this calendar, to allow for chaining.
+func (c *FiscalCalendar) WithStartRule(rule YearStartRule) *FiscalCalendar {
+	c.rule = rule
+	return c
+}
+
+// WithEndYearNaming names the fiscal years after the calendar year in which they end,
+// instead of the one in which they start, i.e. the year starting in October 2023 is the fiscal year 2024.
+// It returns the pointer to this calendar, to allow for chaining.
+func (c *FiscalCalendar) WithEndYearNaming() *FiscalCalendar {
+	c.endYearNaming = true
+	return c
+}
+
+// WithLeapWeekPeriod sets the period, 1 to 12, which gets the 53rd week, the last one by default,
+// and returns the pointer to this calendar, to allow for chaining. The periods out of range are ignored.
+func (c *FiscalCalendar) WithLeapWeekPeriod(period int) *FiscalCalendar {
+	if period >= 1 && period <= 12 {
+		c.leapWeekPeriod = period
+	}
+	return c
+}
+
+// Location returns the location of the calendar.
+func (c *FiscalCalendar) Location() *time.Location {
+	return c.loc
+}
+
+// Date returns the fiscal date of t.
+func (c *FiscalCalendar) Date(t time.Time) FiscalDate {
+	local := t.In(c.loc)
+	day := civilDay(local.Year(), local.Month(), local.Day())
+
+	year := local.Year()
+	if c.endYearNaming && local.Month() >= c.startMonth && c.startMonth != time.January {
+		year++
+	}
+	for day < c.startDay(year) {
+		year--
+	}
+	for day >= c.startDay(year+1) {
+		year++
+	}
+
+	dayOfYear := int(day - c.startDay(year))
+	week := dayOfYear/7 + 1
+	d := FiscalDate{Year: year, Week: week, Day: dayOfYear + 1}
+	weeks := c.WeeksInYear(year)
+	end := 0
+	for p := 1; p <= 12; p++ {
+		end += c.periodWeeks(p, weeks)
+		if week <= end {
+			d.Period = p
+			d.Quarter = (p-1)/3 + 1
+			break
+		}
+	}
+	return d
+}
+
+// WeeksInYear returns the number of weeks of the fiscal year, 52 or 53.
+func (c *FiscalCalendar) WeeksInYear(year int) int {
+	return int((c.startDay(year+1) - c.startDay(year)) / 7)
+}
+
+// YearRange returns the range of the fiscal year.
+func (c *FiscalCalendar) YearRange(year int) Range {
+	return Range{Start: c.dayTime(c.startDay(year)), End: c.dayTime(c.startDay(year + 1))}
+}
+
+// QuarterRange returns the range of the fiscal quarter, 1 to 4.
+func (c *FiscalCalendar) QuarterRange(year, quarter int) (Range, error) {
+	if quarter < 1 || quarter > 4 {
+		return Range{}, fmt.Errorf("invalid fiscal quarter: %d", quarter)
+	}
+	return c.weeksRange(year, 3*(quarter-1)+1, 3*quarter), nil
+}
+
+// PeriodRange returns the range of the fiscal period, 1 to 12.
+func (c *FiscalCalendar) PeriodRange(year, period int) (Range, error) {
+	if period < 1 || period > 12 {
+		return Range{}, fmt.Errorf("invalid fiscal period: %d", period)
+	}
+	return c.weeksRange(year, period, period), nil
+}
+
+// WeekRange returns the range of the fiscal week, 1 to 52 or 53.
+func (c *FiscalCalendar) WeekRange(year, week int) (Range, error) {
+	if week < 1 || week > c.WeeksInYear(year) {
+		return Range{}, fmt.Errorf("invalid week %d of fiscal year %d", week, year)
+	}
+	start := c.startDay(year) + int64(week-1)*7
+	return Range{Start: c.dayTime(start), End: c.dayTime(start + 7)}, nil
+}
+
+// weeksRange returns the range from the start of the first period to the end of the last period.
+func (c *FiscalCalendar) weeksRange(year, first, last int) Range {
+	weeks := c.WeeksInYear(year)
+	var before, through int
+	for p := 1; p <= last; p++ {
+		if p < first {
+			before += c.periodWeeks(p, weeks)
+		}
+		through += c.periodWeeks(p, weeks)
+	}
+	start := c.startDay(year)
+	return Range{Start: c.dayTime(start + int64(before)*7), End: c.dayTime(start + int64(through)*7)}
+}
+
+// periodWeeks returns the number of weeks of the period in the year of given weeks.
+func (c *FiscalCalendar) periodWeeks(period, yearWeeks int) int {
+	n := c.pattern[(period-1)%3]
+	if yearWeeks == 53 && period == c.leapWeekPeriod {
+		n++
+	}
+	return n
+}
+
+// startDay returns the civil day of the fiscal year start.
+func (c *FiscalCalendar) startDay(year int) int64 {
+	if c.endYearNaming && c.startMonth != time.January {
+		year--
+	}
+	anchor := civilDay(year, c.startMonth, 1)
+	diff := (int64(c.weekStart) - civilWeekday(anchor) + 7) % 7
+	switch c.rule {
+	case StartNearest:
+		if diff > 3 {
+			diff -= 7
+		}
+	case StartOnOrBefore:
+		if diff > 0 {
+			diff -= 7
+		}
+	}
+	return anchor + diff
+}
+
+// dayTime returns the start of the civil day in the calendar location.
+func (c *FiscalCalendar) dayTime(day int64) time.Time {
+	t := time.Unix(day*86400, 0).UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, c.loc)
+}
+
+// civilDay returns the number of days since the Unix epoch of the civil date.
+func civilDay(year int, month time.Month, day int) int64 {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
+
+// civilWeekday returns the weekday of the civil day.
+func civilWeekday(day int64) int64 {
+	// The Unix epoch was on Thursday.
+	return ((day+4)%7 + 7) % 7
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"testing"
+	"time"
+)
+
+func TestISOWeekCalendar(t *testing.T) {
+	c := NewISOWeekCalendar(time.UTC)
+	for d := time.Date(1998, 12, 1, 12, 0, 0, 0, time.UTC); d.Year() < 2032; d = d.AddDate(0, 0, 1) {
+		year, week := d.ISOWeek()
+		got := c.Date(d)
+		if got.Year != year || got.Week != week {
+			t.Fatalf("%s: got %v, want year %d week %d", d.Format("2006-01-02"), got, year, week)
+		}
+		if c.WeeksInYear(year) < week {
+			t.Fatalf("%s: week %d over the %d weeks of the year", d.Format("2006-01-02"), week, c.WeeksInYear(year))
+		}
+	}
+	if n := c.WeeksInYear(2020); n != 53 {
+		t.Errorf("2020 has %d weeks, want 53", n)
+	}
+}
+
+func TestFiscalCalendar_Retail454(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The NRF calendar starts on the Sunday nearest to February 1st.
+	c := NewFiscalCalendar(ny, Pattern454, time.February, time.Sunday)
+
+	year := c.YearRange(2023)
+	if want := time.Date(2023, 1, 29, 0, 0, 0, 0, ny); !year.Start.Equal(want) {
+		t.Errorf("year start: got %v, want %v", year.Start, want)
+	}
+	if want := time.Date(2024, 2, 4, 0, 0, 0, 0, ny); !year.End.Equal(want) {
+		t.Errorf("year end: got %v, want %v", year.End, want)
+	}
+	if n := c.WeeksInYear(2023); n != 53 {
+		t.Errorf("2023 has %d weeks, want 53", n)
+	}
+	if n := c.WeeksInYear(2022); n != 52 {
+		t.Errorf("2022 has %d weeks, want 52", n)
+	}
+
+	p2, err := c.PeriodRange(2023, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p2.Start.Equal(time.Date(2023, 2, 26, 0, 0, 0, 0, ny)) || !p2.End.Equal(time.Date(2023, 4, 2, 0, 0, 0, 0, ny)) {
+		t.Errorf("period 2: got %v", p2)
+	}
+	// The 53rd week is added to the last period.
+	p12, _ := c.PeriodRange(2023, 12)
+	if p12.Duration() < 34*24*time.Hour {
+		t.Errorf("period 12 of the 53 week year: got %v", p12)
+	}
+	q4, _ := c.QuarterRange(2023, 4)
+	if !q4.End.Equal(year.End) {
+		t.Errorf("quarter 4 end: got %v, want %v", q4.End, year.End)
+	}
+
+	got := c.Date(time.Date(2024, 2, 3, 23, 0, 0, 0, ny))
+	if want := (FiscalDate{Year: 2023, Quarter: 4, Period: 12, Week: 53, Day: 371}); got != want {
+		t.Errorf("last day: got %v, want %v", got, want)
+	}
+	got = c.Date(time.Date(2023, 3, 1, 12, 0, 0, 0, ny))
+	if want := (FiscalDate{Year: 2023, Quarter: 1, Period: 2, Week: 5, Day: 32}); got != want {
+		t.Errorf("march: got %v, want %v", got, want)
+	}
+
+	if _, err = c.QuarterRange(2023, 5); err == nil {
+		t.Error("expected error for quarter 5")
+	}
+	if _, err = c.WeekRange(2022, 53); err == nil {
+		t.Error("expected error for week 53 of a 52 week year")
+	}
+}
+
+func TestFiscalCalendar_LeapWeekPeriod(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The 53rd week of 2023 is added to the first period.
+	c := NewFiscalCalendar(ny, Pattern454, time.February, time.Sunday).WithLeapWeekPeriod(1)
+
+	p1, err := c.PeriodRange(2023, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 5 * 7 * 24 * time.Hour; p1.Duration() != want {
+		t.Errorf("period 1 of the 53 week year: got %v, want %v", p1.Duration(), want)
+	}
+	got := c.Date(time.Date(2023, 2, 27, 12, 0, 0, 0, ny))
+	if want := (FiscalDate{Year: 2023, Quarter: 1, Period: 1, Week: 5, Day: 30}); got != want {
+		t.Errorf("week 5: got %v, want %v", got, want)
+	}
+	got = c.Date(time.Date(2024, 2, 3, 12, 0, 0, 0, ny))
+	if want := (FiscalDate{Year: 2023, Quarter: 4, Period: 12, Week: 53, Day: 371}); got != want {
+		t.Errorf("last day: got %v, want %v", got, want)
+	}
+	// The 52 week year is not affected.
+	if p1, _ = c.PeriodRange(2022, 1); p1.Duration() != 4*7*24*time.Hour {
+		t.Errorf("period 1 of the 52 week year: got %v", p1.Duration())
+	}
+
+	// The periods out of range are ignored, the 53rd week stays in the last period.
+	for _, period := range []int{0, 13, -1} {
+		c := NewFiscalCalendar(ny, Pattern454, time.February, time.Sunday).WithLeapWeekPeriod(period)
+		got := c.Date(time.Date(2024, 2, 3, 12, 0, 0, 0, ny))
+		if want := (FiscalDate{Year: 2023, Quarter: 4, Period: 12, Week: 53, Day: 371}); got != want {
+			t.Errorf("leap week period %d: got %v, want %v", period, got, want)
+		}
+	}
+}
+
+func TestFiscalCalendar_EndYearNaming(t *testing.T) {
+	c := NewFiscalCalendar(time.UTC, Pattern445, time.October, time.Sunday).
+		WithStartRule(StartOnOrAfter).
+		WithEndYearNaming().
+		WithLeapWeekPeriod(3)
+
+	got := c.Date(time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC))
+	if got.Year != 2024 || got.Week != 3 || got.Period != 1 {
+		t.Errorf("got %v, want year 2024 week 3", got)
+	}
+	if start := c.YearRange(2024).Start; !start.Equal(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("year start: got %v", start)
+	}
+	got = c.Date(time.Date(2023, 9, 30, 0, 0, 0, 0, time.UTC))
+	if got.Year != 2023 {
+		t.Errorf("day before the year start: got %v", got)
+	}
+}
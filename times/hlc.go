@@ -0,0 +1,222 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// timestampSize is the size of the binary encoded Timestamp.
+const timestampSize = 12
+
+// Timestamp is a hybrid logical clock timestamp.
+// It is ordered by the wall time, and then by the logical counter,
+// which orders the events that happened within the same wall time.
+type Timestamp struct {
+	// WallTime is the wall time in nanoseconds since the Unix epoch.
+	WallTime int64
+	// Logical is the logical counter.
+	Logical uint32
+}
+
+// IsZero reports whether t is the zero timestamp.
+func (t Timestamp) IsZero() bool {
+	return t.WallTime == 0 && t.Logical == 0
+}
+
+// Time returns the wall time of the timestamp.
+func (t Timestamp) Time() time.Time {
+	return time.Unix(0, t.WallTime)
+}
+
+// Compare returns -1 if t is before u, +1 if t is after u, and 0 if they are equal.
+func (t Timestamp) Compare(u Timestamp) int {
+	switch {
+	case t.WallTime < u.WallTime:
+		return -1
+	case t.WallTime > u.WallTime:
+		return 1
+	case t.Logical < u.Logical:
+		return -1
+	case t.Logical > u.Logical:
+		return 1
+	}
+	return 0
+}
+
+// Before reports whether t is before u.
+func (t Timestamp) Before(u Timestamp) bool {
+	return t.Compare(u) < 0
+}
+
+// After reports whether t is after u.
+func (t Timestamp) After(u Timestamp) bool {
+	return t.Compare(u) > 0
+}
+
+// String returns the timestamp in the form "<wall time>.<logical>", i.e. "1688553000000000000.2".
+func (t Timestamp) String() string {
+	return strconv.FormatInt(t.WallTime, 10) + "." + strconv.FormatUint(uint64(t.Logical), 10)
+}
+
+// ParseTimestamp parses the timestamp formatted by Timestamp.String.
+func ParseTimestamp(s string) (Timestamp, error) {
+	wall, logical, ok := strings.Cut(s, ".")
+	if !ok {
+		return Timestamp{}, fmt.Errorf("invalid timestamp %q: missing logical counter", s)
+	}
+	w, err := strconv.ParseInt(wall, 10, 64)
+	if err != nil {
+		return Timestamp{}, fmt.Errorf("invalid timestamp %q: %w", s, err)
+	}
+	l, err := strconv.ParseUint(logical, 10, 32)
+	if err != nil {
+		return Timestamp{}, fmt.Errorf("invalid timestamp %q: %w", s, err)
+	}
+	return Timestamp{WallTime: w, Logical: uint32(l)}, nil
+}
+
+// MarshalBinary encodes the timestamp into 12 big endian bytes.
+// The encoded timestamps of non-negative wall times sort bytewise in the timestamp order.
+func (t Timestamp) MarshalBinary() ([]byte, error) {
+	b := make([]byte, timestampSize)
+	binary.BigEndian.PutUint64(b, uint64(t.WallTime))
+	binary.BigEndian.PutUint32(b[8:], t.Logical)
+	return b, nil
+}
+
+// UnmarshalBinary decodes the timestamp encoded by MarshalBinary.
+func (t *Timestamp) UnmarshalBinary(data []byte) error {
+	if len(data) != timestampSize {
+		return fmt.Errorf("invalid timestamp length: %d", len(data))
+	}
+	t.WallTime = int64(binary.BigEndian.Uint64(data))
+	t.Logical = binary.BigEndian.Uint32(data[8:])
+	return nil
+}
+
+// MarshalText encodes the timestamp in the String form.
+func (t Timestamp) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText decodes the timestamp in the String form.
+func (t *Timestamp) UnmarshalText(data []byte) error {
+	ts, err := ParseTimestamp(string(data))
+	if err != nil {
+		return err
+	}
+	*t = ts
+	return nil
+}
+
+// ClockDriftError is returned by HLC.Update, when the remote timestamp is too far ahead of the local clock.
+type ClockDriftError struct {
+	Remote   Timestamp     // The rejected remote timestamp.
+	Drift    time.Duration // The difference between the remote wall time and the local clock.
+	MaxDrift time.Duration // The maximum allowed drift.
+}
+
+func (e *ClockDriftError) Error() string {
+	return fmt.Sprintf("remote timestamp %s is %v ahead of the local clock, exceeds maximum drift %v", e.Remote, e.Drift, e.MaxDrift)
+}
+
+// ErrLogicalOverflow is returned by HLC.Update, when the logical counter would overflow.
+var ErrLogicalOverflow = errors.New("hybrid logical clock counter overflow")
+
+// HLC is a hybrid logical clock. It combines the wall time of the Clock with a logical counter,
+// so that the timestamps respect the causality even if the wall clocks of the nodes are skewed.
+// The timestamps of a single HLC are strictly increasing.
+type HLC struct {
+	clock    Clock
+	maxDrift time.Duration
+
+	mu   sync.Mutex
+	last Timestamp
+}
+
+// NewHLC creates a new hybrid logical clock on top of the clock.
+// The remote timestamps ahead of the clock by more than maxDrift are rejected by Update.
+// A zero maxDrift disables the check.
+func NewHLC(clock Clock, maxDrift time.Duration) *HLC {
+	return &HLC{clock: clock, maxDrift: maxDrift}
+}
+
+// Now returns a new timestamp for the local or send event.
+func (h *HLC) Now() Timestamp {
+	pt := h.clock.UnixNano()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if pt > h.last.WallTime {
+		h.last = Timestamp{WallTime: pt}
+	} else {
+		h.last = h.last.next()
+	}
+	return h.last
+}
+
+// Last returns the most recent timestamp issued by the clock.
+func (h *HLC) Last() Timestamp {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.last
+}
+
+// Update merges the remote timestamp received with a message, and returns the timestamp of the receive event,
+// which is after both the remote timestamp and all the previous timestamps of this clock.
+// If the remote timestamp is too far ahead of the local clock, the ClockDriftError is returned,
+// and the clock is not changed.
+func (h *HLC) Update(remote Timestamp) (Timestamp, error) {
+	pt := h.clock.UnixNano()
+	if drift := time.Duration(remote.WallTime - pt); h.maxDrift > 0 && drift > h.maxDrift {
+		return Timestamp{}, &ClockDriftError{Remote: remote, Drift: drift, MaxDrift: h.maxDrift}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	last := h.last
+	switch {
+	case pt > last.WallTime && pt > remote.WallTime:
+		h.last = Timestamp{WallTime: pt}
+		return h.last, nil
+	case remote.WallTime > last.WallTime:
+		last = remote
+	case remote.WallTime == last.WallTime && remote.Logical > last.Logical:
+		last.Logical = remote.Logical
+	}
+	if last.Logical == math.MaxUint32 {
+		return Timestamp{}, ErrLogicalOverflow
+	}
+	h.last = Timestamp{WallTime: last.WallTime, Logical: last.Logical + 1}
+	return h.last, nil
+}
+
+// next returns the timestamp following t within the same wall time.
+// When the logical counter is exhausted, the wall time is advanced by a nanosecond.
+func (t Timestamp) next() Timestamp {
+	if t.Logical == math.MaxUint32 {
+		return Timestamp{WallTime: t.WallTime + 1}
+	}
+	return Timestamp{WallTime: t.WallTime, Logical: t.Logical + 1}
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHLC(t *testing.T) {
+	start := time.Date(2023, 7, 5, 10, 30, 0, 0, time.UTC)
+	clock := newFixedClock(start)
+	h := NewHLC(clock, time.Second)
+
+	t1 := h.Now()
+	if t1 != (Timestamp{WallTime: start.UnixNano()}) {
+		t.Fatalf("first: got %v", t1)
+	}
+	// The wall clock did not move, the logical counter orders the events.
+	t2 := h.Now()
+	if t2 != (Timestamp{WallTime: start.UnixNano(), Logical: 1}) {
+		t.Fatalf("second: got %v", t2)
+	}
+
+	// The remote clock is ahead, its wall time is adopted.
+	remote := Timestamp{WallTime: start.Add(500 * time.Millisecond).UnixNano(), Logical: 7}
+	t3, err := h.Update(remote)
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if t3 != (Timestamp{WallTime: remote.WallTime, Logical: 8}) {
+		t.Fatalf("update: got %v", t3)
+	}
+
+	// The local wall clock went backwards, the timestamps still increase.
+	clock.now = start.Add(-time.Second)
+	if t4 := h.Now(); !t4.After(t3) {
+		t.Fatalf("after clock went back: got %v, want after %v", t4, t3)
+	}
+
+	// The local wall clock caught up.
+	clock.now = start.Add(time.Second)
+	t5, err := h.Update(remote)
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if t5 != (Timestamp{WallTime: clock.now.UnixNano()}) {
+		t.Fatalf("caught up: got %v", t5)
+	}
+
+	// The remote timestamp too far ahead is rejected.
+	far := Timestamp{WallTime: clock.now.Add(2 * time.Second).UnixNano()}
+	_, err = h.Update(far)
+	var driftErr *ClockDriftError
+	if !errors.As(err, &driftErr) || driftErr.Drift != 2*time.Second {
+		t.Fatalf("drift: got %v", err)
+	}
+	if h.Last() != t5 {
+		t.Fatalf("rejected update changed the clock: got %v", h.Last())
+	}
+}
+
+func TestTimestamp_Encoding(t *testing.T) {
+	ts := Timestamp{WallTime: 1688553000123456789, Logical: 42}
+
+	s := ts.String()
+	if s != "1688553000123456789.42" {
+		t.Errorf("String: got %q", s)
+	}
+	parsed, err := ParseTimestamp(s)
+	if err != nil || parsed != ts {
+		t.Errorf("ParseTimestamp: got %v, %v", parsed, err)
+	}
+	for _, invalid := range []string{"", "123", "a.1", "1.-1", "1.4294967296"} {
+		if _, err = ParseTimestamp(invalid); err == nil {
+			t.Errorf("ParseTimestamp(%q): expected error", invalid)
+		}
+	}
+
+	b, err := ts.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Timestamp
+	if err = decoded.UnmarshalBinary(b); err != nil || decoded != ts {
+		t.Errorf("UnmarshalBinary: got %v, %v", decoded, err)
+	}
+
+	// The binary form sorts in the timestamp order.
+	next, _ := Timestamp{WallTime: ts.WallTime, Logical: ts.Logical + 1}.MarshalBinary()
+	later, _ := Timestamp{WallTime: ts.WallTime + 1}.MarshalBinary()
+	if bytes.Compare(b, next) >= 0 || bytes.Compare(next, later) >= 0 {
+		t.Errorf("binary encoding is not ordered")
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ZoneResolver returns the time zone name for the request, or an empty string if the request does not determine it.
+// An error is returned, when the zone could not be resolved, i.e. the user lookup failed.
+type ZoneResolver func(r *http.Request) (string, error)
+
+// ZoneFromHeader resolves the zone from the request header, i.e. "Time-Zone".
+func ZoneFromHeader(name string) ZoneResolver {
+	return func(r *http.Request) (string, error) {
+		return r.Header.Get(name), nil
+	}
+}
+
+// ZoneFromCookie resolves the zone from the request cookie.
+func ZoneFromCookie(name string) ZoneResolver {
+	return func(r *http.Request) (string, error) {
+		c, err := r.Cookie(name)
+		if err != nil {
+			return "", nil
+		}
+		return c.Value, nil
+	}
+}
+
+// ZoneFromQuery resolves the zone from the URL query parameter.
+func ZoneFromQuery(param string) ZoneResolver {
+	return func(r *http.Request) (string, error) {
+		return r.URL.Query().Get(param), nil
+	}
+}
+
+// ZoneFromUser resolves the zone with the lookup of the authenticated user's preference,
+// i.e. by the user the authentication middleware stored in the request context.
+// The lookup returns an empty string, if there is no authenticated user, or the user has no preferred zone.
+func ZoneFromUser(lookup func(ctx context.Context) (string, error)) ZoneResolver {
+	return func(r *http.Request) (string, error) {
+		name, err := lookup(r.Context())
+		if err != nil {
+			return "", fmt.Errorf("user time zone lookup: %w", err)
+		}
+		return name, nil
+	}
+}
+
+// InvalidZoneError is returned when the resolved zone name is not a valid location.
+type InvalidZoneError struct {
+	Name string // The resolved zone name.
+	Err  error  // The error of the location loading.
+}
+
+func (e *InvalidZoneError) Error() string {
+	return fmt.Sprintf("invalid time zone %q: %v", e.Name, e.Err)
+}
+
+func (e *InvalidZoneError) Unwrap() error {
+	return e.Err
+}
+
+// ZoneMiddleware is an HTTP middleware, which determines the time zone of the request,
+// and stores the ZonedClock of that zone in the request context.
+// The resolvers are tried in order, and the first valid zone wins.
+// If none of them determines the zone, the default location is used.
+type ZoneMiddleware struct {
+	def          *time.Location
+	resolvers    []ZoneResolver
+	errorHandler func(w http.ResponseWriter, r *http.Request, err error)
+	locations    sync.Map
+}
+
+// NewZoneMiddleware creates a new ZoneMiddleware with the default location and the chain of resolvers.
+// The nil default location is UTC.
+func NewZoneMiddleware(def *time.Location, resolvers ...ZoneResolver) *ZoneMiddleware {
+	if def == nil {
+		def = time.UTC
+	}
+	return &ZoneMiddleware{def: def, resolvers: resolvers}
+}
+
+// WithErrorHandler sets the handler of the resolver errors and invalid zones,
+// which is responsible for writing the response, as the request is not passed further.
+// Without the handler, such resolvers are skipped, and the chain continues.
+// It returns the pointer to this middleware, to allow for chaining.
+func (m *ZoneMiddleware) WithErrorHandler(h func(w http.ResponseWriter, r *http.Request, err error)) *ZoneMiddleware {
+	m.errorHandler = h
+	return m
+}
+
+// Handler wraps the next handler, passing it the request with the ZonedClock in its context.
+func (m *ZoneMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		loc, err := m.Resolve(r)
+		if err != nil {
+			if m.errorHandler != nil {
+				m.errorHandler(w, r, err)
+				return
+			}
+			loc = m.def
+		}
+		next.ServeHTTP(w, r.WithContext(ContextWithClock(r.Context(), NewZonedClock(loc))))
+	})
+}
+
+// Resolve returns the location of the request.
+// If the error handler is not set, the failing resolvers are skipped, and the error is never returned.
+func (m *ZoneMiddleware) Resolve(r *http.Request) (*time.Location, error) {
+	for _, resolve := range m.resolvers {
+		name, err := resolve(r)
+		if err == nil && name != "" {
+			var loc *time.Location
+			if loc, err = m.load(name); err == nil {
+				return loc, nil
+			}
+		}
+		if err != nil && m.errorHandler != nil {
+			return nil, err
+		}
+	}
+	return m.def, nil
+}
+
+// load loads the location of the name, caching the loaded ones.
+func (m *ZoneMiddleware) load(name string) (*time.Location, error) {
+	if loc, ok := m.locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	// The "Local" location is the zone of the server, not of the caller.
+	if name == "Local" {
+		return nil, &InvalidZoneError{Name: name, Err: fmt.Errorf("local zone is not allowed")}
+	}
+	loc, err := LoadLocation(name)
+	if err != nil {
+		return nil, &InvalidZoneError{Name: name, Err: err}
+	}
+	m.locations.Store(name, loc)
+	return loc, nil
+}
+
+type clockContextKey struct{}
+
+// ContextWithClock returns the copy of the context carrying the clock.
+func ContextWithClock(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, clockContextKey{}, c)
+}
+
+// ClockFromContext returns the clock stored in the context, i.e. by the ZoneMiddleware.
+func ClockFromContext(ctx context.Context) (Clock, bool) {
+	c, ok := ctx.Value(clockContextKey{}).(Clock)
+	return c, ok
+}
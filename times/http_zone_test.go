@@ -0,0 +1,162 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestZoneMiddleware(t *testing.T) {
+	userZones := map[string]string{"alice": "Asia/Tokyo", "bob": "Mars/Olympus"}
+	errLookup := errors.New("lookup failed")
+	fromUser := func(r *http.Request) (string, error) {
+		user := r.Header.Get("X-User")
+		if user == "carol" {
+			return "", errLookup
+		}
+		return userZones[user], nil
+	}
+
+	serve := func(m *ZoneMiddleware, r *http.Request) (*httptest.ResponseRecorder, string) {
+		var got string
+		h := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c, ok := ClockFromContext(r.Context())
+			if !ok {
+				t.Fatal("no clock in the context")
+			}
+			got = c.Location().String()
+		}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w, got
+	}
+
+	m := NewZoneMiddleware(time.UTC,
+		ZoneFromHeader("Time-Zone"),
+		ZoneFromCookie("tz"),
+		ZoneFromQuery("tz"),
+		fromUser,
+	)
+	for _, test := range []struct {
+		desc   string
+		setup  func(r *http.Request)
+		target string
+		want   string
+	}{
+		{desc: "default", want: "UTC"},
+		{desc: "header", setup: func(r *http.Request) { r.Header.Set("Time-Zone", "Europe/Berlin") }, want: "Europe/Berlin"},
+		{desc: "cookie", setup: func(r *http.Request) { r.AddCookie(&http.Cookie{Name: "tz", Value: "America/New_York"}) }, want: "America/New_York"},
+		{desc: "query", target: "/?tz=Europe/Warsaw", want: "Europe/Warsaw"},
+		{desc: "user", setup: func(r *http.Request) { r.Header.Set("X-User", "alice") }, want: "Asia/Tokyo"},
+		{
+			desc: "header before query",
+			setup: func(r *http.Request) {
+				r.Header.Set("Time-Zone", "Europe/Berlin")
+			},
+			target: "/?tz=Europe/Warsaw",
+			want:   "Europe/Berlin",
+		},
+		{desc: "invalid header skipped", setup: func(r *http.Request) { r.Header.Set("Time-Zone", "Nowhere/City") }, target: "/?tz=Europe/Warsaw", want: "Europe/Warsaw"},
+		{desc: "local rejected", setup: func(r *http.Request) { r.Header.Set("Time-Zone", "Local") }, want: "UTC"},
+		{desc: "invalid user zone", setup: func(r *http.Request) { r.Header.Set("X-User", "bob") }, want: "UTC"},
+		{desc: "user lookup error", setup: func(r *http.Request) { r.Header.Set("X-User", "carol") }, want: "UTC"},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			target := test.target
+			if target == "" {
+				target = "/"
+			}
+			r := httptest.NewRequest(http.MethodGet, target, nil)
+			if test.setup != nil {
+				test.setup(r)
+			}
+			if _, got := serve(m, r); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+
+	// With the error handler, the invalid zones and lookup errors are rejected.
+	var handled error
+	strict := NewZoneMiddleware(time.UTC, ZoneFromHeader("Time-Zone"), fromUser).
+		WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			handled = err
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Time-Zone", "Nowhere/City")
+	w, got := serve(strict, r)
+	var zoneErr *InvalidZoneError
+	if w.Code != http.StatusBadRequest || got != "" || !errors.As(handled, &zoneErr) || zoneErr.Name != "Nowhere/City" {
+		t.Errorf("invalid zone: code %d, zone %q, error %v", w.Code, got, handled)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-User", "carol")
+	if w, _ = serve(strict, r); w.Code != http.StatusBadRequest || !errors.Is(handled, errLookup) {
+		t.Errorf("lookup error: code %d, error %v", w.Code, handled)
+	}
+}
+
+func TestZoneFromUser(t *testing.T) {
+	type userKey struct{}
+	errLookup := errors.New("lookup failed")
+	resolve := ZoneFromUser(func(ctx context.Context) (string, error) {
+		switch user, _ := ctx.Value(userKey{}).(string); user {
+		case "alice":
+			return "Asia/Tokyo", nil
+		case "carol":
+			return "", errLookup
+		}
+		return "", nil
+	})
+	// The nil default location is UTC.
+	m := NewZoneMiddleware(nil, resolve)
+
+	for _, test := range []struct {
+		user string
+		want string
+	}{
+		{user: "alice", want: "Asia/Tokyo"},
+		{user: "", want: "UTC"},
+		{user: "carol", want: "UTC"},
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), userKey{}, test.user))
+		loc, err := m.Resolve(r)
+		if err != nil || loc.String() != test.want {
+			t.Errorf("user %q: got %v, %v, want %s", test.user, loc, err, test.want)
+		}
+		if _, err := resolve(r); test.user == "carol" && !errors.Is(err, errLookup) {
+			t.Errorf("user %q: got error %v, want %v", test.user, err, errLookup)
+		}
+	}
+}
+
+func TestClockFromContext(t *testing.T) {
+	if _, ok := ClockFromContext(context.Background()); ok {
+		t.Error("clock found in the empty context")
+	}
+	c := NewZonedClock(time.UTC)
+	if got, ok := ClockFromContext(ContextWithClock(context.Background(), c)); !ok || got != c {
+		t.Errorf("got %v, %v", got, ok)
+	}
+}
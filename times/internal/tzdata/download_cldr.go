@@ -0,0 +1,198 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tzdata
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+)
+
+const (
+	metaZonesURL = `https://raw.githubusercontent.com/unicode-org/cldr/main/common/supplemental/metaZones.xml`
+	localeURL    = `https://raw.githubusercontent.com/unicode-org/cldr/main/common/main/%s.xml`
+)
+
+type MetaZonesData struct {
+	MetaZones MetaZones `xml:"metaZones"`
+}
+type MetaZones struct {
+	Timezones []MetaZoneTimezone `xml:"metazoneInfo>timezone"`
+}
+type MetaZoneTimezone struct {
+	Type          string         `xml:"type,attr"`
+	UsesMetazones []UsesMetazone `xml:"usesMetazone"`
+}
+type UsesMetazone struct {
+	MZone string `xml:"mzone,attr"`
+	From  string `xml:"from,attr"`
+	To    string `xml:"to,attr"`
+}
+
+type LDML struct {
+	TimeZoneNames TimeZoneNames `xml:"dates>timeZoneNames"`
+}
+type TimeZoneNames struct {
+	Zones     []ZoneNames `xml:"zone"`
+	Metazones []ZoneNames `xml:"metazone"`
+}
+type ZoneNames struct {
+	Type  string        `xml:"type,attr"`
+	Long  *NameVariants `xml:"long"`
+	Short *NameVariants `xml:"short"`
+}
+type NameVariants struct {
+	Generic  string `xml:"generic"`
+	Standard string `xml:"standard"`
+	Daylight string `xml:"daylight"`
+}
+
+// DownloadMetaZones fetches the metazone mapping from unicode.org.
+func DownloadMetaZones() (MetaZonesData, error) {
+	var data MetaZonesData
+	err := downloadXML(metaZonesURL, &data)
+	return data, err
+}
+
+// DownloadTimeZoneNames fetches the time zone names of the locale from unicode.org.
+func DownloadTimeZoneNames(locale string) (TimeZoneNames, error) {
+	var data LDML
+	err := downloadXML(fmt.Sprintf(localeURL, locale), &data)
+	return data.TimeZoneNames, err
+}
+
+func downloadXML(url string, v any) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download \"%v\", http error: %v", url, resp.StatusCode)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return xml.Unmarshal(b, v)
+}
+
+// UpdateTimeZoneNames writes the Go source of the localized time zone names to the target writer.
+// The metazones of each zone are written with their validity periods, in the unix seconds.
+func UpdateTimeZoneNames(target io.Writer, meta MetaZonesData, names map[string]TimeZoneNames) error {
+	metazones := make(map[string][]UsesMetazone)
+	for _, tz := range meta.MetaZones.Timezones {
+		metazones[tz.Type] = tz.UsesMetazones
+	}
+
+	out := bytes.Buffer{}
+	out.WriteString("// Localized time zone names of the CLDR.\n\n")
+	out.WriteString(fmt.Sprintf("// Last created %v\n\n", time.Now().UTC().Format(time.RFC3339)))
+
+	out.WriteString("// metazones maps the CLDR zone identifiers to their metazone periods.\n")
+	out.WriteString("var metazones = map[string][]metazonePeriod{\n")
+	for _, zone := range sortedKeys(metazones) {
+		out.WriteString(fmt.Sprintf("\t%q: {", zone))
+		for i, mz := range metazones[zone] {
+			from, err := metazoneTime(mz.From)
+			if err != nil {
+				return err
+			}
+			to, err := metazoneTime(mz.To)
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				out.WriteString(", ")
+			}
+			out.WriteString(fmt.Sprintf("{%q, %d, %d}", mz.MZone, from, to))
+		}
+		out.WriteString("},\n")
+	}
+	out.WriteString("}\n\n")
+
+	writeNames := func(name, doc string, get func(TimeZoneNames) []ZoneNames) {
+		out.WriteString(doc)
+		out.WriteString(fmt.Sprintf("var %s = map[string]map[string]Names{\n", name))
+		for _, locale := range sortedKeys(names) {
+			entries := get(names[locale])
+			sort.Slice(entries, func(i, j int) bool { return entries[i].Type < entries[j].Type })
+
+			out.WriteString(fmt.Sprintf("\t%q: {\n", locale))
+			for _, e := range entries {
+				if e.Long == nil && e.Short == nil {
+					continue
+				}
+				out.WriteString(fmt.Sprintf("\t\t%q: {", e.Type))
+				sep := ""
+				if e.Long != nil {
+					out.WriteString("Long: " + variantsSource(*e.Long))
+					sep = ", "
+				}
+				if e.Short != nil {
+					out.WriteString(sep + "Short: " + variantsSource(*e.Short))
+				}
+				out.WriteString("},\n")
+			}
+			out.WriteString("\t},\n")
+		}
+		out.WriteString("}\n\n")
+	}
+	writeNames("metazoneNames", "// metazoneNames maps the locales and metazones to their names.\n",
+		func(n TimeZoneNames) []ZoneNames { return n.Metazones })
+	writeNames("zoneNames", "// zoneNames maps the locales and CLDR zone identifiers to their zone specific names.\n",
+		func(n TimeZoneNames) []ZoneNames { return n.Zones })
+
+	_, err := target.Write(bytes.TrimSuffix(out.Bytes(), []byte("\n")))
+	return err
+}
+
+// metazoneTime parses the UTC time of the metazone period, i.e. "1991-03-31 00:00", to the unix seconds.
+// The empty time, the unbounded period, is zero.
+func metazoneTime(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid metazone period time %q: %w", s, err)
+	}
+	return t.Unix(), nil
+}
+
+func variantsSource(v NameVariants) string {
+	var b bytes.Buffer
+	b.WriteString("Variants{")
+	sep := ""
+	for _, f := range []struct{ name, value string }{
+		{"Generic", v.Generic},
+		{"Standard", v.Standard},
+		{"Daylight", v.Daylight},
+	} {
+		if f.value == "" {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("%s%s: %q", sep, f.name, f.value))
+		sep = ", "
+	}
+	b.WriteString("}")
+	return b.String()
+}
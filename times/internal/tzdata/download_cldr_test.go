@@ -0,0 +1,32 @@
+package tzdata
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestUpdateTimeZoneNames(t *testing.T) {
+	meta := MetaZonesData{MetaZones: MetaZones{Timezones: []MetaZoneTimezone{{
+		Type: "Europe/Moscow",
+		UsesMetazones: []UsesMetazone{
+			{MZone: "Moscow", To: "2011-03-26 23:00"},
+			{MZone: "Europe_Eastern", From: "2011-03-26 23:00", To: "2014-10-25 22:00"},
+			{MZone: "Moscow", From: "2014-10-25 22:00"},
+		},
+	}}}}
+
+	var b bytes.Buffer
+	if err := UpdateTimeZoneNames(&b, meta, nil); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	want := `"Europe/Moscow": {{"Moscow", 0, 1301180400}, {"Europe_Eastern", 1301180400, 1414274400}, {"Moscow", 1414274400, 0}},`
+	if !strings.Contains(b.String(), want) {
+		t.Errorf("got %s, want the periods %s", b.String(), want)
+	}
+
+	meta.MetaZones.Timezones[0].UsesMetazones[0].To = "2011-03-27"
+	if err := UpdateTimeZoneNames(&b, meta, nil); err == nil {
+		t.Error("invalid period time: got nil error")
+	}
+}
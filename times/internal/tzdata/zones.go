@@ -0,0 +1,194 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tzdata
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ZoneTables contains the zone tables of the IANA time zone database.
+type ZoneTables struct {
+	// Zones maps a zone name to the ISO 3166 codes of the countries it is used in.
+	Zones map[string][]string
+	// Links maps a backward compatible zone name to its target.
+	Links map[string]string
+	// Countries maps an ISO 3166 country code to the country name.
+	Countries map[string]string
+}
+
+// DownloadZoneTables fetches the zone tables from the latest tzdata release.
+func DownloadZoneTables() (ZoneTables, error) {
+	buf, err := FTPDownload(tzdataURL)
+	if err != nil {
+		return ZoneTables{}, err
+	}
+	data := buf.Bytes()
+
+	return parseZoneTables(func(name string) (io.Reader, error) {
+		var b bytes.Buffer
+		if err := ExtractTarGz(bytes.NewReader(data), &b, name); err != nil {
+			return nil, err
+		}
+		return &b, nil
+	}, "backward")
+}
+
+// ReadZoneTables reads the zone tables from the local tzdata directory,
+// i.e. /usr/share/zoneinfo. Links are read from the tzdata.zi file.
+func ReadZoneTables(dir string) (ZoneTables, error) {
+	return parseZoneTables(func(name string) (io.Reader, error) {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(b), nil
+	}, "tzdata.zi")
+}
+
+func parseZoneTables(open func(name string) (io.Reader, error), linksFile string) (ZoneTables, error) {
+	tables := ZoneTables{
+		Zones:     make(map[string][]string),
+		Links:     make(map[string]string),
+		Countries: make(map[string]string),
+	}
+
+	// zone1970.tab lists every canonical zone with all the countries using it,
+	// while zone.tab additionally lists links used as the main zone of a country.
+	for _, name := range []string{"zone1970.tab", "zone.tab"} {
+		r, err := open(name)
+		if err != nil {
+			return tables, err
+		}
+		err = readTab(r, 3, func(fields []string) {
+			zone := fields[2]
+			for _, cc := range strings.Split(fields[0], ",") {
+				if !containsString(tables.Zones[zone], cc) {
+					tables.Zones[zone] = append(tables.Zones[zone], cc)
+				}
+			}
+		})
+		if err != nil {
+			return tables, fmt.Errorf("failed to read %q: %w", name, err)
+		}
+	}
+
+	r, err := open("iso3166.tab")
+	if err != nil {
+		return tables, err
+	}
+	err = readTab(r, 2, func(fields []string) {
+		tables.Countries[fields[0]] = fields[1]
+	})
+	if err != nil {
+		return tables, fmt.Errorf("failed to read %q: %w", "iso3166.tab", err)
+	}
+
+	r, err = open(linksFile)
+	if err != nil {
+		return tables, err
+	}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		parts := strings.Fields(sc.Text())
+		if len(parts) < 3 || (parts[0] != "Link" && parts[0] != "L") {
+			continue
+		}
+		tables.Links[parts[2]] = parts[1]
+	}
+	if err = sc.Err(); err != nil {
+		return tables, fmt.Errorf("failed to read %q: %w", linksFile, err)
+	}
+	return tables, nil
+}
+
+// readTab reads the tab separated file, skipping comments.
+func readTab(r io.Reader, minFields int, fn func(fields []string)) error {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if len(strings.TrimSpace(line)) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) < minFields {
+			continue
+		}
+		fn(fields)
+	}
+	return sc.Err()
+}
+
+func containsString(s []string, v string) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateZones writes the Go source of the zone tables to the target writer.
+func UpdateZones(target io.Writer, tables ZoneTables) error {
+	out := bytes.Buffer{}
+	out.WriteString("// Zone tables of the IANA time zone database.\n\n")
+	out.WriteString(fmt.Sprintf("// Last created %v\n\n", time.Now().UTC().Format(time.RFC3339)))
+
+	out.WriteString("// Zones lists the IANA zones used by countries, with their ISO 3166 country codes.\n")
+	out.WriteString("var Zones = []Zone{\n")
+	for _, name := range sortedKeys(tables.Zones) {
+		cc := tables.Zones[name]
+		sort.Strings(cc)
+		out.WriteString(fmt.Sprintf("\t{Name: %q, Countries: []string{%q", name, cc[0]))
+		for _, c := range cc[1:] {
+			out.WriteString(fmt.Sprintf(", %q", c))
+		}
+		out.WriteString("}},\n")
+	}
+	out.WriteString("}\n\n")
+
+	out.WriteString("// Links maps backward compatible zone names to their targets.\n")
+	out.WriteString("var Links = map[string]string{\n")
+	for _, name := range sortedKeys(tables.Links) {
+		out.WriteString(fmt.Sprintf("\t%q: %q,\n", name, tables.Links[name]))
+	}
+	out.WriteString("}\n\n")
+
+	out.WriteString("// Countries maps ISO 3166 country codes to the country names.\n")
+	out.WriteString("var Countries = map[string]string{\n")
+	for _, cc := range sortedKeys(tables.Countries) {
+		out.WriteString(fmt.Sprintf("\t%q: %q,\n", cc, tables.Countries[cc]))
+	}
+	out.WriteString("}\n")
+
+	_, err := target.Write(out.Bytes())
+	return err
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tzlocal
+
+import (
+	"io/fs"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ReadLinkFS is the file system which can read the symbolic links.
+// The symbolic links of the file systems not implementing it, like testing/fstest.MapFS,
+// are the files with the fs.ModeSymlink mode, which content is the link target.
+type ReadLinkFS interface {
+	fs.FS
+	// ReadLink returns the destination of the named symbolic link.
+	ReadLink(name string) (string, error)
+	// Lstat returns the file info of the named file, without following the symbolic link.
+	Lstat(name string) (fs.FileInfo, error)
+}
+
+// state is the state of the local time zone detection, which is overridden by the tests.
+var state struct {
+	mu       sync.Mutex
+	fsys     fs.FS  // The file system root of the zone files, nil for the OS.
+	override string // The zone name returned by RuntimeTZ, if not empty.
+	local    atomic.Pointer[localLocation]
+}
+
+type localLocation struct {
+	loc *time.Location
+	err error
+}
+
+// SetOverride makes RuntimeTZ return the name, or detect the zone again if the name is empty.
+// It resets the cached local location, and returns the function restoring the previous override.
+func SetOverride(name string) (restore func()) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	prev := state.override
+	state.override = name
+	state.local.Store(nil)
+	return func() { SetOverride(prev) }
+}
+
+// SetFS makes the detection read the zone files, i.e. "etc/localtime", from the file system root,
+// or from the OS if fsys is nil. It has no effect on Windows, where the zone is read from the registry.
+// It resets the cached local location, and returns the function restoring the previous file system.
+func SetFS(fsys fs.FS) (restore func()) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	prev := state.fsys
+	state.fsys = fsys
+	state.local.Store(nil)
+	return func() { SetFS(prev) }
+}
+
+// ResetLocal drops the cached local location, so that it is detected again by LocalLocation.
+func ResetLocal() {
+	state.local.Store(nil)
+}
+
+// LocalLocation returns the location of the RuntimeTZ, which is detected once and cached.
+func LocalLocation() (*time.Location, error) {
+	if l := state.local.Load(); l != nil {
+		return l.loc, l.err
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if l := state.local.Load(); l != nil {
+		return l.loc, l.err
+	}
+	l := &localLocation{}
+	var name string
+	if name, _, l.err = runtimeTZ(); l.err == nil {
+		l.loc, l.err = time.LoadLocation(name)
+	}
+	state.local.Store(l)
+	return l.loc, l.err
+}
+
+// currentFS returns the overridden file system, or nil for the OS.
+func currentFS() fs.FS {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return state.fsys
+}
@@ -37,18 +37,44 @@ func EnvTZ() (string, bool) {
 	return "", false
 }
 
+// Source is the strategy that detected the local timezone.
+type Source string
+
+const (
+	// SourceOverride is the timezone set by SetOverride.
+	SourceOverride Source = "override"
+	// SourceEnv is the timezone of the TZ env variable.
+	SourceEnv Source = "TZ environment variable"
+)
+
 // RuntimeTZ get the full timezone name of the local machine
 func RuntimeTZ() (string, error) {
+	name, _, err := RuntimeTZSource()
+	return name, err
+}
+
+// RuntimeTZSource get the full timezone name of the local machine, and the strategy that detected it.
+func RuntimeTZSource() (string, Source, error) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return runtimeTZ()
+}
+
+// runtimeTZ is the RuntimeTZSource, called with the state locked.
+func runtimeTZ() (string, Source, error) {
+	if state.override != "" {
+		return state.override, SourceOverride, nil
+	}
 	// Get the timezone from the TZ env variable
 	if name, ok := EnvTZ(); ok {
-		return name, nil
+		return name, SourceEnv, nil
 	}
 	// Get the timezone from the system file
-	name, err := LocalTZ()
+	name, err := localTZ(state.fsys)
 	if err != nil {
 		err = fmt.Errorf("failed to get local machine timezone: %w", err)
-		return "", err
+		return "", SourceSystem, err
 	}
 
-	return name, err
+	return name, SourceSystem, err
 }
@@ -19,13 +19,31 @@ package tzlocal
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path"
+	"strings"
 )
 
 const localZoneFile = "/etc/localtime" // symlinked file - set by OS
 
+// SourceSystem is the timezone of the /etc/localtime symlink.
+const SourceSystem Source = localZoneFile
+
 func inferFromPath(p string) (string, error) {
+	// handles the zone names of any depth, e.g. /usr/share/zoneinfo/America/Argentina/Salta
+	if i := strings.LastIndex(p, "/zoneinfo/"); i >= 0 && i+len("/zoneinfo/") < len(p) {
+		name := p[i+len("/zoneinfo/"):]
+		// the posix and right trees hold the same zones, without and with the leap seconds,
+		// e.g. /usr/share/zoneinfo/right/Europe/Berlin is Europe/Berlin
+		for _, tree := range []string{"posix/", "right/"} {
+			if trimmed := strings.TrimPrefix(name, tree); trimmed != name && trimmed != "" {
+				return trimmed, nil
+			}
+		}
+		return name, nil
+	}
+
 	var name string
 	var err error
 	dir, lname := path.Split(p)
@@ -48,6 +66,14 @@ func inferFromPath(p string) (string, error) {
 
 // LocalTZ will run `/etc/localtime` and get the timezone from the resulting value `/usr/share/zoneinfo/America/New_York`
 func LocalTZ() (string, error) {
+	return localTZ(currentFS())
+}
+
+// localTZ reads the `/etc/localtime` from the file system root, or from the OS if fsys is nil.
+func localTZ(fsys fs.FS) (string, error) {
+	if fsys != nil {
+		return localTZFromFS(fsys)
+	}
 	var name string
 	fi, err := os.Lstat(localZoneFile)
 	if err != nil {
@@ -69,3 +95,34 @@ func LocalTZ() (string, error) {
 	name, err = inferFromPath(p)
 	return name, err
 }
+
+// localTZFromFS reads the `etc/localtime` symlink from the file system root.
+func localTZFromFS(fsys fs.FS) (string, error) {
+	name := localZoneFile[1:]
+	var fi fs.FileInfo
+	var err error
+	if rl, ok := fsys.(ReadLinkFS); ok {
+		fi, err = rl.Lstat(name)
+	} else {
+		fi, err = fs.Stat(fsys, name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to stat %q: %w", localZoneFile, err)
+	}
+	if (fi.Mode() & fs.ModeSymlink) == 0 {
+		return "", fmt.Errorf("%q is not a symlink - cannot infer name", localZoneFile)
+	}
+
+	var p string
+	if rl, ok := fsys.(ReadLinkFS); ok {
+		p, err = rl.ReadLink(name)
+	} else {
+		var b []byte
+		b, err = fs.ReadFile(fsys, name)
+		p = string(b)
+	}
+	if err != nil {
+		return "", err
+	}
+	return inferFromPath(p)
+}
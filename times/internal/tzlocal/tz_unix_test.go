@@ -29,3 +29,38 @@ func TestInferFromPathSuccess(t *testing.T) {
 		t.Errorf("got tz=%s; want: %s", tz, want)
 	}
 }
+
+func TestInferFromPathThreeParts(t *testing.T) {
+	tz, err := inferFromPath("/usr/share/zoneinfo/America/Argentina/Salta")
+	if err != nil {
+		t.Errorf("got err=%d; want: nil", err)
+	}
+	want := "America/Argentina/Salta"
+	if tz != want {
+		t.Errorf("got tz=%s; want: %s", tz, want)
+	}
+}
+
+func TestInferFromPathLeapSecondTrees(t *testing.T) {
+	for _, p := range []string{
+		"/usr/share/zoneinfo/posix/Europe/Berlin",
+		"/usr/share/zoneinfo/right/Europe/Berlin",
+	} {
+		tz, err := inferFromPath(p)
+		if err != nil {
+			t.Errorf("%s: got err=%v; want: nil", p, err)
+		}
+		want := "Europe/Berlin"
+		if tz != want {
+			t.Errorf("%s: got tz=%s; want: %s", p, tz, want)
+		}
+	}
+
+	tz, err := inferFromPath("/usr/share/zoneinfo/posix/Japan")
+	if err != nil {
+		t.Errorf("got err=%v; want: nil", err)
+	}
+	if want := "Japan"; tz != want {
+		t.Errorf("got tz=%s; want: %s", tz, want)
+	}
+}
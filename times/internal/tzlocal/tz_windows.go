@@ -18,6 +18,7 @@ package tzlocal
 
 import (
 	"fmt"
+	"io/fs"
 	"os/exec"
 	"strings"
 
@@ -27,6 +28,9 @@ import (
 const tzKey = `SYSTEM\CurrentControlSet\Control\TimeZoneInformation`
 const tzKeyVal = "TimeZoneKeyName"
 
+// SourceSystem is the timezone of the Windows settings, read with tzutil or from the registry.
+const SourceSystem Source = "Windows time zone settings"
+
 // LocalTZ obtains the name of the time zone Windows is configured to use. Returns the corresponding IANA standard name
 func LocalTZ() (string, error) {
 	var winTZname string
@@ -47,6 +51,11 @@ func LocalTZ() (string, error) {
 	return "", fmt.Errorf("could not find IANA tz name for set time zone \"%s\"", winTZname)
 }
 
+// localTZ ignores the file system root, as the zone is read from the registry.
+func localTZ(fs.FS) (string, error) {
+	return LocalTZ()
+}
+
 // localTZfromTzutil executes command `tzutil /g` to get the name of the time zone Windows is configured to use.
 func localTZfromTzutil() (string, error) {
 	cmd := exec.Command("tzutil", "/g")
@@ -0,0 +1,79 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+
+	"github.com/blockysource/go-pkg/times/internal/tzdata"
+)
+
+// The zone tables are downloaded from the latest tzdata release,
+// unless the -dir flag points to a local tzdata directory (i.e. /usr/share/zoneinfo).
+func main() {
+	dir := flag.String("dir", "", "local tzdata directory to read the zone tables from")
+	flag.Parse()
+
+	var tables tzdata.ZoneTables
+	var err error
+	if *dir != "" {
+		tables, err = tzdata.ReadZoneTables(*dir)
+	} else {
+		tables, err = tzdata.DownloadZoneTables()
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var out bytes.Buffer
+	out.WriteString(licenseHeader)
+	out.WriteString("// Code generated by tzzones/update_zones.go DO NOT EDIT.\n")
+	out.WriteString("package tzzones\n\n")
+	if err = tzdata.UpdateZones(&out, tables); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		panic(err)
+	}
+
+	path, _ := filepath.Abs("./zones.go")
+	if err = os.WriteFile(path, src, 0644); err != nil {
+		panic(err)
+	}
+}
+
+const licenseHeader = `// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+`
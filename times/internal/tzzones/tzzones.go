@@ -0,0 +1,26 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tzzones contains the zone tables of the IANA time zone database.
+package tzzones
+
+//go:generate go run ./cmd/update_zones.go
+
+// Zone is a time zone used by at least one country.
+type Zone struct {
+	// Name is the IANA name of the zone, i.e. Europe/Berlin.
+	Name string
+	// Countries are the ISO 3166 codes of the countries using the zone.
+	Countries []string
+}
@@ -0,0 +1,260 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"sort"
+	"time"
+)
+
+// Transition is a change of the zone offset of a location.
+type Transition struct {
+	// At is the instant the zone takes effect.
+	At time.Time
+	// Name is the abbreviated zone name, i.e. "CEST".
+	Name string
+	// Offset is the offset from UTC in seconds.
+	Offset int
+	// IsDST reports whether the zone is the daylight saving time.
+	IsDST bool
+}
+
+// Transitions returns the transitions of the location between from and to,
+// starting with the zone in effect at from.
+func Transitions(loc *time.Location, from, to time.Time) []Transition {
+	var res []Transition
+	for t := from.In(loc); t.Before(to); {
+		name, offset := t.Zone()
+		isDST := t.IsDST()
+		// The zones computed from the TZ rule are bounded by the years, without the change of the zone.
+		if n := len(res); n == 0 || res[n-1].Name != name || res[n-1].Offset != offset || res[n-1].IsDST != isDST {
+			res = append(res, Transition{At: t, Name: name, Offset: offset, IsDST: isDST})
+		}
+		_, end := t.ZoneBounds()
+		if end.IsZero() {
+			break
+		}
+		if !end.After(t) {
+			// The bound at the year boundary may be reported as the end of the zone starting at it.
+			end = t.Add(time.Second)
+		}
+		t = end.In(loc)
+	}
+	return res
+}
+
+// CivilTime is the local wall clock time of an instant.
+type CivilTime struct {
+	Year       int
+	Month      time.Month
+	Day        int
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+	Weekday    time.Weekday
+	// Name is the abbreviated zone name, i.e. "CEST".
+	Name string
+	// Offset is the offset from UTC in seconds.
+	Offset int
+}
+
+// OffsetCache converts the instants to the civil times of a location and back,
+// using the transitions precomputed over a range of years.
+// The conversions are binary searches in the transition table, instead of the per call zone lookup
+// and calendar computation of time.Time, which makes them fast for bulk conversions.
+// The instants out of the cached range fall back to the standard library.
+// The cache is immutable, and safe for concurrent use.
+type OffsetCache struct {
+	loc      *time.Location
+	from, to int64 // The cached range in Unix seconds.
+	starts   []int64
+	zones    []cachedZone
+}
+
+type cachedZone struct {
+	name   string
+	offset int
+}
+
+// NewOffsetCache precomputes the transitions of the location from the start of fromYear to the end of toYear.
+func NewOffsetCache(loc *time.Location, fromYear, toYear int) *OffsetCache {
+	from := time.Date(fromYear, time.January, 1, 0, 0, 0, 0, time.UTC).Add(-14 * time.Hour)
+	to := time.Date(toYear+1, time.January, 1, 0, 0, 0, 0, time.UTC).Add(14 * time.Hour)
+
+	c := &OffsetCache{loc: loc, from: from.Unix(), to: to.Unix()}
+	for _, tr := range Transitions(loc, from, to) {
+		c.starts = append(c.starts, tr.At.Unix())
+		c.zones = append(c.zones, cachedZone{name: tr.Name, offset: tr.Offset})
+	}
+	return c
+}
+
+// Location returns the location of the cache.
+func (c *OffsetCache) Location() *time.Location {
+	return c.loc
+}
+
+// Transitions returns the cached transitions, starting with the zone in effect at the start of the range.
+func (c *OffsetCache) Transitions() []Transition {
+	res := make([]Transition, len(c.starts))
+	for i, start := range c.starts {
+		t := time.Unix(start, 0).In(c.loc)
+		res[i] = Transition{At: t, Name: c.zones[i].name, Offset: c.zones[i].offset, IsDST: t.IsDST()}
+	}
+	return res
+}
+
+// lookup returns the zone in effect at the Unix time, and the bounds of the zone.
+func (c *OffsetCache) lookup(unix int64) (zone cachedZone, start, end int64) {
+	i := sort.Search(len(c.starts), func(i int) bool { return c.starts[i] > unix }) - 1
+	if i < 0 {
+		i = 0
+	}
+	start, end = c.starts[i], c.to
+	if i+1 < len(c.starts) {
+		end = c.starts[i+1]
+	}
+	return c.zones[i], start, end
+}
+
+// inRange reports whether the Unix time is cached.
+func (c *OffsetCache) inRange(unix int64) bool {
+	return len(c.starts) > 0 && unix >= c.from && unix < c.to
+}
+
+// Zone returns the abbreviated name and the offset in seconds of the zone in effect at t, like time.Time.Zone.
+func (c *OffsetCache) Zone(t time.Time) (name string, offset int) {
+	unix := t.Unix()
+	if !c.inRange(unix) {
+		return t.In(c.loc).Zone()
+	}
+	z, _, _ := c.lookup(unix)
+	return z.name, z.offset
+}
+
+// Civil returns the civil time of t in the location.
+func (c *OffsetCache) Civil(t time.Time) CivilTime {
+	unix := t.Unix()
+	if !c.inRange(unix) {
+		lt := t.In(c.loc)
+		name, offset := lt.Zone()
+		return CivilTime{
+			Year: lt.Year(), Month: lt.Month(), Day: lt.Day(),
+			Hour: lt.Hour(), Minute: lt.Minute(), Second: lt.Second(), Nanosecond: lt.Nanosecond(),
+			Weekday: lt.Weekday(), Name: name, Offset: offset,
+		}
+	}
+
+	z, _, _ := c.lookup(unix)
+	local := unix + int64(z.offset)
+	days, secs := floorDiv(local, 86400)
+	year, month, day := civilFromDays(days)
+	return CivilTime{
+		Year:       year,
+		Month:      month,
+		Day:        day,
+		Hour:       int(secs / 3600),
+		Minute:     int(secs % 3600 / 60),
+		Second:     int(secs % 60),
+		Nanosecond: t.Nanosecond(),
+		Weekday:    time.Weekday(civilWeekday(days)),
+		Name:       z.name,
+		Offset:     z.offset,
+	}
+}
+
+// In returns t in the location, like time.Time.In.
+func (c *OffsetCache) In(t time.Time) time.Time {
+	return t.In(c.loc)
+}
+
+// Date returns the instant of the civil time in the location, like time.Date,
+// including the choice of the zone for the wall times skipped or repeated by a transition.
+func (c *OffsetCache) Date(year int, month time.Month, day, hour, min, sec, nsec int) time.Time {
+	// The normalization of the out of range fields is left to the standard library.
+	if month < time.January || month > time.December || day < 1 || day > daysIn(month, year) ||
+		hour < 0 || hour > 23 || min < 0 || min > 59 || sec < 0 || sec > 59 || nsec < 0 || nsec >= 1e9 {
+		return time.Date(year, month, day, hour, min, sec, nsec, c.loc)
+	}
+
+	unix := (daysFromCivil(year, month, day)*24+int64(hour))*3600 + int64(min)*60 + int64(sec)
+	if !c.inRange(unix) {
+		return time.Date(year, month, day, hour, min, sec, nsec, c.loc)
+	}
+
+	// The same zone choice as in time.Date: look up the zone at the local time taken as UTC,
+	// and if the adjusted instant is out of its bounds, use the zone of the adjusted instant.
+	z, start, end := c.lookup(unix)
+	offset := z.offset
+	if offset != 0 {
+		utc := unix - int64(offset)
+		if utc < start || utc >= end {
+			z, _, _ = c.lookup(utc)
+			offset = z.offset
+		}
+		unix -= int64(offset)
+	}
+	return time.Unix(unix, int64(nsec)).In(c.loc)
+}
+
+// floorDiv returns the floored quotient and the non-negative remainder.
+func floorDiv(a, b int64) (q, r int64) {
+	q, r = a/b, a%b
+	if r < 0 {
+		q--
+		r += b
+	}
+	return q, r
+}
+
+// civilFromDays converts the days since the Unix epoch to the proleptic Gregorian date.
+// See http://howardhinnant.github.io/date_algorithms.html#civil_from_days.
+func civilFromDays(days int64) (int, time.Month, int) {
+	z := days + 719468
+	era, doe := floorDiv(z, 146097)
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365
+	y := yoe + era*400
+	doy := doe - (365*yoe + yoe/4 - yoe/100)
+	mp := (5*doy + 2) / 153
+	d := doy - (153*mp+2)/5 + 1
+	m := mp + 3
+	if m > 12 {
+		m -= 12
+	}
+	if m <= 2 {
+		y++
+	}
+	return int(y), time.Month(m), int(d)
+}
+
+// daysFromCivil converts the proleptic Gregorian date to the days since the Unix epoch.
+// See http://howardhinnant.github.io/date_algorithms.html#days_from_civil.
+func daysFromCivil(year int, month time.Month, day int) int64 {
+	y := int64(year)
+	if month <= time.February {
+		y--
+	}
+	era, yoe := floorDiv(y, 400)
+	m := int64(month)
+	if m > 2 {
+		m -= 3
+	} else {
+		m += 9
+	}
+	doy := (153*m+2)/5 + int64(day) - 1
+	doe := yoe*365 + yoe/4 - yoe/100 + doy
+	return era*146097 + doe - 719468
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestOffsetCache_Civil(t *testing.T) {
+	for _, name := range []string{"Europe/Berlin", "America/Sao_Paulo", "Australia/Lord_Howe", "Asia/Kolkata", "Pacific/Apia", "UTC"} {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := NewOffsetCache(loc, 1900, 2040)
+		rnd := rand.New(rand.NewSource(1))
+		from := time.Date(1880, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+		to := time.Date(2060, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+		for i := 0; i < 20000; i++ {
+			tm := time.Unix(from+rnd.Int63n(to-from), rnd.Int63n(1e9))
+			lt := tm.In(loc)
+			name, offset := lt.Zone()
+			want := CivilTime{
+				Year: lt.Year(), Month: lt.Month(), Day: lt.Day(),
+				Hour: lt.Hour(), Minute: lt.Minute(), Second: lt.Second(), Nanosecond: lt.Nanosecond(),
+				Weekday: lt.Weekday(), Name: name, Offset: offset,
+			}
+			if got := c.Civil(tm); got != want {
+				t.Fatalf("%s %v: got %+v, want %+v", loc, tm.UTC(), got, want)
+			}
+		}
+	}
+}
+
+func TestOffsetCache_Date(t *testing.T) {
+	for _, name := range []string{"Europe/Berlin", "America/New_York", "Australia/Lord_Howe", "America/Sao_Paulo"} {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := NewOffsetCache(loc, 1970, 2040)
+		// Every half hour walks through the skipped and repeated wall times.
+		for d := time.Date(1971, 1, 1, 0, 0, 0, 0, time.UTC); d.Year() < 2040; d = d.Add(30*time.Minute + 7*24*time.Hour) {
+			want := time.Date(d.Year(), d.Month(), d.Day(), d.Hour(), d.Minute(), 0, 0, loc)
+			if got := c.Date(d.Year(), d.Month(), d.Day(), d.Hour(), d.Minute(), 0, 0); !got.Equal(want) {
+				t.Fatalf("%s %v: got %v, want %v", loc, d, got, want)
+			}
+		}
+		for _, tr := range c.Transitions()[1:] {
+			for _, shift := range []time.Duration{-90 * time.Minute, -30 * time.Minute, 0, 30 * time.Minute, 90 * time.Minute} {
+				d := tr.At.Add(shift).In(loc)
+				want := time.Date(d.Year(), d.Month(), d.Day(), d.Hour(), d.Minute(), 0, 0, loc)
+				if got := c.Date(d.Year(), d.Month(), d.Day(), d.Hour(), d.Minute(), 0, 0); !got.Equal(want) {
+					t.Fatalf("%s %v: got %v, want %v", loc, d, got, want)
+				}
+			}
+		}
+	}
+
+	c := NewOffsetCache(time.UTC, 2000, 2010)
+	if got, want := c.Date(2023, 2, 30, 25, 0, 0, 0), time.Date(2023, 2, 30, 25, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("normalized: got %v, want %v", got, want)
+	}
+}
+
+func TestTransitions(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := Transitions(berlin, time.Date(2023, 1, 1, 0, 0, 0, 0, berlin), time.Date(2024, 1, 1, 0, 0, 0, 0, berlin))
+	if len(got) != 3 {
+		t.Fatalf("got %d transitions: %v", len(got), got)
+	}
+	if got[1].Name != "CEST" || !got[1].IsDST || !got[1].At.Equal(time.Date(2023, 3, 26, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("DST start: got %+v", got[1])
+	}
+	if got[2].Name != "CET" || got[2].Offset != 3600 || !got[2].At.Equal(time.Date(2023, 10, 29, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("DST end: got %+v", got[2])
+	}
+}
+
+func benchmarkInstants() []time.Time {
+	rnd := rand.New(rand.NewSource(1))
+	from := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	res := make([]time.Time, 1024)
+	for i := range res {
+		res[i] = time.Unix(from+rnd.Int63n(30*365*86400), 0)
+	}
+	return res
+}
+
+func BenchmarkOffsetCache_Civil(b *testing.B) {
+	loc, _ := time.LoadLocation("America/New_York")
+	c := NewOffsetCache(loc, 2000, 2030)
+	instants := benchmarkInstants()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = c.Civil(instants[i%len(instants)])
+	}
+}
+
+func BenchmarkStdlib_Civil(b *testing.B) {
+	loc, _ := time.LoadLocation("America/New_York")
+	instants := benchmarkInstants()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		t := instants[i%len(instants)].In(loc)
+		t.Date()
+		t.Clock()
+		t.Weekday()
+		t.Zone()
+	}
+}
+
+func BenchmarkOffsetCache_Date(b *testing.B) {
+	loc, _ := time.LoadLocation("America/New_York")
+	c := NewOffsetCache(loc, 2000, 2030)
+	instants := benchmarkInstants()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		t := instants[i%len(instants)]
+		_ = c.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0)
+	}
+}
+
+func BenchmarkStdlib_Date(b *testing.B) {
+	loc, _ := time.LoadLocation("America/New_York")
+	instants := benchmarkInstants()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		t := instants[i%len(instants)]
+		_ = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc)
+	}
+}
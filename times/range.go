@@ -0,0 +1,403 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Range is the half-open time range [Start, End).
+// The range with End not after Start is empty.
+type Range struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// NewRange creates a new range starting at start and lasting for d.
+func NewRange(start time.Time, d time.Duration) Range {
+	return Range{Start: start, End: start.Add(d)}
+}
+
+// IsEmpty reports whether the range contains no instant.
+func (r Range) IsEmpty() bool {
+	return !r.End.After(r.Start)
+}
+
+// Duration returns the length of the range, zero if it is empty.
+func (r Range) Duration() time.Duration {
+	if r.IsEmpty() {
+		return 0
+	}
+	return r.End.Sub(r.Start)
+}
+
+// Contains reports whether t is within the range.
+func (r Range) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && t.Before(r.End)
+}
+
+// Overlaps reports whether the ranges have a common instant.
+func (r Range) Overlaps(o Range) bool {
+	return !r.IsEmpty() && !o.IsEmpty() && r.Start.Before(o.End) && o.Start.Before(r.End)
+}
+
+// Equal reports whether the ranges contain the same instants.
+func (r Range) Equal(o Range) bool {
+	if r.IsEmpty() || o.IsEmpty() {
+		return r.IsEmpty() == o.IsEmpty()
+	}
+	return r.Start.Equal(o.Start) && r.End.Equal(o.End)
+}
+
+// Intersect returns the common part of the ranges, which is empty if they do not overlap.
+func (r Range) Intersect(o Range) Range {
+	res := Range{Start: laterTime(r.Start, o.Start), End: earlierTime(r.End, o.End)}
+	if res.IsEmpty() {
+		return Range{}
+	}
+	return res
+}
+
+// Union returns the range covering both ranges, if they overlap or are adjacent.
+// Otherwise, the union is not a single range, and false is returned.
+func (r Range) Union(o Range) (Range, bool) {
+	switch {
+	case o.IsEmpty():
+		return r, true
+	case r.IsEmpty():
+		return o, true
+	case r.Start.After(o.End) || o.Start.After(r.End):
+		return Range{}, false
+	}
+	return Range{Start: earlierTime(r.Start, o.Start), End: laterTime(r.End, o.End)}, true
+}
+
+// Subtract returns the parts of the range not covered by o, at most two ranges.
+func (r Range) Subtract(o Range) []Range {
+	if r.IsEmpty() {
+		return nil
+	}
+	if !r.Overlaps(o) {
+		return []Range{r}
+	}
+	var res []Range
+	if r.Start.Before(o.Start) {
+		res = append(res, Range{Start: r.Start, End: o.Start})
+	}
+	if o.End.Before(r.End) {
+		res = append(res, Range{Start: o.End, End: r.End})
+	}
+	return res
+}
+
+// SplitDays splits the range at the midnights of the location, so that each part is within a single local day.
+func (r Range) SplitDays(loc *time.Location) []Range {
+	if r.IsEmpty() {
+		return nil
+	}
+	var res []Range
+	for start := r.Start; start.Before(r.End); {
+		local := start.In(loc)
+		// The midnight missing due to a DST transition is normalized to the first instant of the day.
+		end := time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, loc)
+		if end.After(r.End) {
+			end = r.End
+		}
+		res = append(res, Range{Start: start, End: end})
+		start = end
+	}
+	return res
+}
+
+// Slots returns the iterator over the consecutive slots of the step within the range.
+// The calendar steps are applied in the location, the location of the range start if nil,
+// and the last slot is cut at the range end.
+func (r Range) Slots(step Step, loc *time.Location) *SlotIterator {
+	if loc == nil {
+		loc = r.Start.Location()
+	}
+	return &SlotIterator{r: r, step: step, loc: loc}
+}
+
+// String returns the range in the form "[start, end)".
+func (r Range) String() string {
+	return "[" + r.Start.Format(time.RFC3339Nano) + ", " + r.End.Format(time.RFC3339Nano) + ")"
+}
+
+// UnmarshalJSON decodes the range, rejecting the one ending before its start.
+func (r *Range) UnmarshalJSON(data []byte) error {
+	type plain Range
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	if p.End.Before(p.Start) {
+		return fmt.Errorf("invalid time range: end %s is before start %s", p.End.Format(time.RFC3339Nano), p.Start.Format(time.RFC3339Nano))
+	}
+	*r = Range(p)
+	return nil
+}
+
+// Step is the length of the slots iterated by Range.Slots.
+// The calendar part is added in the location, which keeps the local wall time over the DST transitions,
+// and the Duration is added afterwards. The years and months clamp the day to the end of the month,
+// i.e. the monthly slots from January 31 start on February 28, March 31 and April 30.
+type Step struct {
+	Years, Months, Days int
+	Duration            time.Duration
+}
+
+// FixedStep returns the step of a fixed duration.
+func FixedStep(d time.Duration) Step {
+	return Step{Duration: d}
+}
+
+// CalendarStep returns the step of the calendar years, months and days.
+func CalendarStep(years, months, days int) Step {
+	return Step{Years: years, Months: months, Days: days}
+}
+
+// isZero reports whether the step does not advance the time.
+func (s Step) isZero() bool {
+	return s.Years == 0 && s.Months == 0 && s.Days == 0 && s.Duration <= 0
+}
+
+// SlotIterator iterates over the slots of a Range.
+type SlotIterator struct {
+	r    Range
+	step Step
+	loc  *time.Location
+	i    int
+	slot Range
+	done bool
+}
+
+// Next advances to the next slot, and reports whether there is one.
+func (it *SlotIterator) Next() bool {
+	if it.done || it.step.isZero() || it.r.IsEmpty() {
+		return false
+	}
+	// Each boundary is computed from the range start, so that the day of month clamping does not accumulate.
+	start := it.boundary(it.i)
+	if !start.Before(it.r.End) {
+		it.done = true
+		return false
+	}
+	end := it.boundary(it.i + 1)
+	if !end.After(start) {
+		it.done = true
+		return false
+	}
+	if end.After(it.r.End) {
+		end = it.r.End
+	}
+	it.slot = Range{Start: start, End: end}
+	it.i++
+	return true
+}
+
+// Slot returns the current slot.
+func (it *SlotIterator) Slot() Range {
+	return it.slot
+}
+
+func (it *SlotIterator) boundary(i int) time.Time {
+	if i == 0 {
+		return it.r.Start
+	}
+	s := it.step
+	start := addMonths(it.r.Start.In(it.loc), (12*s.Years+s.Months)*i)
+	return start.AddDate(0, 0, s.Days*i).Add(s.Duration * time.Duration(i))
+}
+
+// RangeSet is a set of instants, represented as sorted, non-overlapping and non-adjacent ranges.
+// The operations return new sets, the zero RangeSet is empty.
+type RangeSet struct {
+	ranges []Range
+}
+
+// NewRangeSet creates a new set of the union of the ranges.
+func NewRangeSet(ranges ...Range) RangeSet {
+	rs := make([]Range, 0, len(ranges))
+	for _, r := range ranges {
+		if !r.IsEmpty() {
+			rs = append(rs, r)
+		}
+	}
+	sort.Slice(rs, func(i, j int) bool { return rs[i].Start.Before(rs[j].Start) })
+
+	var res []Range
+	for _, r := range rs {
+		if n := len(res); n > 0 {
+			if u, ok := res[n-1].Union(r); ok {
+				res[n-1] = u
+				continue
+			}
+		}
+		res = append(res, r)
+	}
+	return RangeSet{ranges: res}
+}
+
+// Ranges returns the ranges of the set, sorted by their start.
+func (s RangeSet) Ranges() []Range {
+	return append([]Range(nil), s.ranges...)
+}
+
+// IsEmpty reports whether the set contains no instant.
+func (s RangeSet) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+// Duration returns the total length of the set.
+func (s RangeSet) Duration() time.Duration {
+	var d time.Duration
+	for _, r := range s.ranges {
+		d += r.Duration()
+	}
+	return d
+}
+
+// Bounds returns the smallest range covering the set.
+func (s RangeSet) Bounds() Range {
+	if s.IsEmpty() {
+		return Range{}
+	}
+	return Range{Start: s.ranges[0].Start, End: s.ranges[len(s.ranges)-1].End}
+}
+
+// Contains reports whether t is within the set.
+func (s RangeSet) Contains(t time.Time) bool {
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].End.After(t) })
+	return i < len(s.ranges) && s.ranges[i].Contains(t)
+}
+
+// Overlaps reports whether the set has a common instant with the range.
+func (s RangeSet) Overlaps(r Range) bool {
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].End.After(r.Start) })
+	return i < len(s.ranges) && s.ranges[i].Overlaps(r)
+}
+
+// Add returns the set with the range added.
+func (s RangeSet) Add(r Range) RangeSet {
+	return NewRangeSet(append(s.Ranges(), r)...)
+}
+
+// Union returns the set of the instants in either set.
+func (s RangeSet) Union(o RangeSet) RangeSet {
+	return NewRangeSet(append(s.Ranges(), o.ranges...)...)
+}
+
+// Intersect returns the set of the instants in both sets.
+func (s RangeSet) Intersect(o RangeSet) RangeSet {
+	var res []Range
+	for i, j := 0, 0; i < len(s.ranges) && j < len(o.ranges); {
+		a, b := s.ranges[i], o.ranges[j]
+		if x := a.Intersect(b); !x.IsEmpty() {
+			res = append(res, x)
+		}
+		if a.End.Before(b.End) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return RangeSet{ranges: res}
+}
+
+// Subtract returns the set of the instants in s, but not in o.
+func (s RangeSet) Subtract(o RangeSet) RangeSet {
+	var res []Range
+	j := 0
+	for _, r := range s.ranges {
+		// Skip the subtracted ranges ending before r.
+		for j < len(o.ranges) && !o.ranges[j].End.After(r.Start) {
+			j++
+		}
+		rest := r
+		for k := j; k < len(o.ranges) && o.ranges[k].Start.Before(rest.End); k++ {
+			if rest.Start.Before(o.ranges[k].Start) {
+				res = append(res, Range{Start: rest.Start, End: o.ranges[k].Start})
+			}
+			rest.Start = laterTime(rest.Start, o.ranges[k].End)
+			if rest.IsEmpty() {
+				break
+			}
+		}
+		if !rest.IsEmpty() {
+			res = append(res, rest)
+		}
+	}
+	return RangeSet{ranges: res}
+}
+
+// Gaps returns the parts of the range within not covered by the set.
+func (s RangeSet) Gaps(within Range) RangeSet {
+	return NewRangeSet(within).Subtract(s)
+}
+
+// SplitDays splits the ranges of the set at the midnights of the location.
+func (s RangeSet) SplitDays(loc *time.Location) []Range {
+	var res []Range
+	for _, r := range s.ranges {
+		res = append(res, r.SplitDays(loc)...)
+	}
+	return res
+}
+
+// String returns the ranges of the set, separated by commas, in braces.
+func (s RangeSet) String() string {
+	parts := make([]string, len(s.ranges))
+	for i, r := range s.ranges {
+		parts[i] = r.String()
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// MarshalJSON encodes the set as the array of its ranges.
+func (s RangeSet) MarshalJSON() ([]byte, error) {
+	if s.ranges == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s.ranges)
+}
+
+// UnmarshalJSON decodes the array of ranges, merging the overlapping ones.
+func (s *RangeSet) UnmarshalJSON(data []byte) error {
+	var ranges []Range
+	if err := json.Unmarshal(data, &ranges); err != nil {
+		return err
+	}
+	*s = NewRangeSet(ranges...)
+	return nil
+}
+
+func earlierTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func laterTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
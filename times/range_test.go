@@ -0,0 +1,184 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestRange(t *testing.T) {
+	h := func(hour int) time.Time { return time.Date(2023, 7, 5, hour, 0, 0, 0, time.UTC) }
+	r := Range{Start: h(9), End: h(17)}
+
+	if !r.Contains(h(9)) || r.Contains(h(17)) {
+		t.Error("Contains: the range is not half-open")
+	}
+	if r.Overlaps(Range{Start: h(17), End: h(18)}) {
+		t.Error("Overlaps: adjacent ranges overlap")
+	}
+	if got := r.Intersect(Range{Start: h(12), End: h(20)}); !got.Equal(Range{Start: h(12), End: h(17)}) {
+		t.Errorf("Intersect: got %v", got)
+	}
+	if got := r.Intersect(Range{Start: h(18), End: h(20)}); !got.IsEmpty() {
+		t.Errorf("Intersect of disjoint ranges: got %v", got)
+	}
+	if got, ok := r.Union(Range{Start: h(17), End: h(18)}); !ok || !got.Equal(Range{Start: h(9), End: h(18)}) {
+		t.Errorf("Union of adjacent ranges: got %v, %v", got, ok)
+	}
+	if _, ok := r.Union(Range{Start: h(18), End: h(19)}); ok {
+		t.Error("Union of disjoint ranges is a single range")
+	}
+	got := r.Subtract(Range{Start: h(12), End: h(13)})
+	if len(got) != 2 || !got[0].Equal(Range{Start: h(9), End: h(12)}) || !got[1].Equal(Range{Start: h(13), End: h(17)}) {
+		t.Errorf("Subtract: got %v", got)
+	}
+	if got := r.Subtract(Range{Start: h(8), End: h(18)}); len(got) != 0 {
+		t.Errorf("Subtract covering range: got %v", got)
+	}
+}
+
+func TestRange_SplitDays(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The night of the DST end, the day of 2023-10-29 lasts 25 hours.
+	r := Range{
+		Start: time.Date(2023, 10, 28, 22, 0, 0, 0, berlin),
+		End:   time.Date(2023, 10, 30, 2, 0, 0, 0, berlin),
+	}
+	days := r.SplitDays(berlin)
+	if len(days) != 3 {
+		t.Fatalf("got %d days: %v", len(days), days)
+	}
+	for i, want := range []time.Duration{2 * time.Hour, 25 * time.Hour, 2 * time.Hour} {
+		if days[i].Duration() != want {
+			t.Errorf("day %d: got %v, want %v", i, days[i].Duration(), want)
+		}
+	}
+}
+
+func TestRange_Slots(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := Range{
+		Start: time.Date(2023, 1, 31, 0, 0, 0, 0, berlin),
+		End:   time.Date(2023, 5, 15, 0, 0, 0, 0, berlin),
+	}
+	var starts []string
+	for it := r.Slots(CalendarStep(0, 1, 0), berlin); it.Next(); {
+		starts = append(starts, it.Slot().Start.Format("2006-01-02"))
+	}
+	// The boundaries are computed from the start, and clamped to the end of the month.
+	want := []string{"2023-01-31", "2023-02-28", "2023-03-31", "2023-04-30"}
+	if len(starts) != len(want) {
+		t.Fatalf("got slots %v, want %v", starts, want)
+	}
+	for i := range want {
+		if starts[i] != want[i] {
+			t.Errorf("slot %d: got %s, want %s", i, starts[i], want[i])
+		}
+	}
+
+	var n int
+	var last Range
+	for it := NewRange(r.Start, 150*time.Minute).Slots(FixedStep(time.Hour), berlin); it.Next(); n++ {
+		last = it.Slot()
+	}
+	if n != 3 || last.Duration() != 30*time.Minute {
+		t.Errorf("fixed slots: got %d, last %v", n, last)
+	}
+
+	// The nil location is the one of the range start.
+	it := NewRange(r.Start, 48*time.Hour).Slots(CalendarStep(0, 0, 1), nil)
+	if !it.Next() || !it.Next() || it.Slot().Start.Location() != berlin || it.Next() {
+		t.Errorf("nil location: got %v", it.Slot())
+	}
+
+	if r.Slots(Step{}, berlin).Next() {
+		t.Error("zero step iterates")
+	}
+}
+
+func TestRangeSet(t *testing.T) {
+	h := func(hour int) time.Time { return time.Date(2023, 7, 5, hour, 0, 0, 0, time.UTC) }
+	rg := func(start, end int) Range { return Range{Start: h(start), End: h(end)} }
+	equal := func(s RangeSet, want ...Range) bool {
+		got := s.Ranges()
+		if len(got) != len(want) {
+			return false
+		}
+		for i := range got {
+			if !got[i].Equal(want[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	s := NewRangeSet(rg(12, 14), rg(8, 10), rg(9, 11), rg(14, 15), rg(16, 16))
+	if !equal(s, rg(8, 11), rg(12, 15)) {
+		t.Fatalf("NewRangeSet: got %v", s)
+	}
+	if s.Duration() != 6*time.Hour || !s.Bounds().Equal(rg(8, 15)) {
+		t.Errorf("Duration %v, Bounds %v", s.Duration(), s.Bounds())
+	}
+	if !s.Contains(h(10)) || s.Contains(h(11)) || !s.Overlaps(rg(10, 12)) || s.Overlaps(rg(11, 12)) {
+		t.Error("Contains or Overlaps is wrong")
+	}
+
+	o := NewRangeSet(rg(7, 9), rg(10, 13), rg(14, 18))
+	if got := s.Union(o); !equal(got, rg(7, 18)) {
+		t.Errorf("Union: got %v", got)
+	}
+	if got := s.Intersect(o); !equal(got, rg(8, 9), rg(10, 11), rg(12, 13), rg(14, 15)) {
+		t.Errorf("Intersect: got %v", got)
+	}
+	if got := s.Subtract(o); !equal(got, rg(9, 10), rg(13, 14)) {
+		t.Errorf("Subtract: got %v", got)
+	}
+	if got := s.Gaps(rg(6, 20)); !equal(got, rg(6, 8), rg(11, 12), rg(15, 20)) {
+		t.Errorf("Gaps: got %v", got)
+	}
+	if got := s.Add(rg(11, 12)); !equal(got, rg(8, 15)) {
+		t.Errorf("Add: got %v", got)
+	}
+}
+
+func TestRangeSet_JSON(t *testing.T) {
+	var s RangeSet
+	data := `[{"start":"2023-07-05T12:00:00Z","end":"2023-07-05T14:00:00Z"},{"start":"2023-07-05T08:00:00+02:00","end":"2023-07-05T13:00:00Z"}]`
+	if err := json.Unmarshal([]byte(data), &s); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[{"start":"2023-07-05T08:00:00+02:00","end":"2023-07-05T14:00:00Z"}]`; string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+
+	if b, _ = json.Marshal(RangeSet{}); string(b) != "[]" {
+		t.Errorf("empty set: got %s", b)
+	}
+	if err = json.Unmarshal([]byte(`[{"start":"2023-07-05T12:00:00Z","end":"2023-07-05T11:00:00Z"}]`), &s); err == nil {
+		t.Error("expected error for the range ending before its start")
+	}
+}
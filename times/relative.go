@@ -0,0 +1,588 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RelativeTime is the result of ParseRelative.
+// It is either an instant, or a half-open range [Start, End).
+type RelativeTime struct {
+	// Start is the resolved instant, or the start of the resolved range.
+	Start time.Time
+	// End is the exclusive end of the resolved range. It equals Start for an instant.
+	End time.Time
+	// Interpretation describes how the input was interpreted,
+	// i.e. "Friday of next week (2023-07-14) at 09:00".
+	Interpretation string
+}
+
+// IsRange checks if the relative time is a range, rather than an instant.
+func (r RelativeTime) IsRange() bool {
+	return r.End.After(r.Start)
+}
+
+// AmbiguousTimeError is returned by ParseRelative when the input has multiple interpretations.
+type AmbiguousTimeError struct {
+	Input        string         // The parsed input.
+	Alternatives []RelativeTime // The possible interpretations.
+}
+
+func (e *AmbiguousTimeError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "ambiguous time %q, it could be ", e.Input)
+	for i, alt := range e.Alternatives {
+		if i > 0 {
+			b.WriteString(" or ")
+		}
+		b.WriteString(alt.Interpretation)
+	}
+	return b.String()
+}
+
+// ParseRelative parses the English natural-language time expression
+// relative to the clock's current time and location. The supported expressions are:
+//
+//	now
+//	in 3 hours, in a day, 2 weeks ago, 10 minutes from now
+//	today, tomorrow, yesterday
+//	friday, this friday, next friday, last friday
+//	2023-07-14
+//	this week, next month, last year
+//	start of next week, beginning of the month, end of month, end of today
+//	9am, 9:30 pm, 21:00, noon, midnight
+//
+// Days may be combined with the time of day, i.e. "tomorrow 9am", "next friday at 17:00"
+// or "in 2 days at noon". A day without the time of day resolves to the range of that day,
+// and the week, month and year expressions resolve to the range of that period.
+// The "end of" expressions resolve to the instant the period ends, i.e. midnight starting the next month.
+// The month and year offsets clamp the day to the end of the month, i.e. "in a month" on January 31 is February 28 or 29.
+// Weeks start on Monday. The time of day skipped by the daylight saving time change is an error.
+//
+// Instead of guessing, an *AmbiguousTimeError is returned for the inputs such as:
+//
+//	"next friday" on Wednesday - this week's or next week's Friday
+//	"friday" on Friday         - today or next week
+//	"at 9"                     - 09:00 or 21:00
+//	"9am" after 9am            - today, which has passed, or tomorrow
+//	"2:30am" as clocks go back - the first or the second 02:30
+func ParseRelative(input string, clock Clock) (RelativeTime, error) {
+	p := relativeParser{input: input, now: clock.Now().In(clock.Location())}
+	alts, err := p.parse()
+	if err != nil {
+		return RelativeTime{}, err
+	}
+	if len(alts) > 1 {
+		return RelativeTime{}, &AmbiguousTimeError{Input: input, Alternatives: alts}
+	}
+	return alts[0], nil
+}
+
+type relativeParser struct {
+	input string
+	now   time.Time
+}
+
+// dayExpr is a resolved day with its description.
+type dayExpr struct {
+	day  time.Time // Midnight of the day.
+	desc string
+}
+
+// clockExpr is a resolved time of day with its description.
+type clockExpr struct {
+	hour, minute int
+	desc         string
+}
+
+func (p *relativeParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid relative time %q: %s", p.input, fmt.Sprintf(format, args...))
+}
+
+func (p *relativeParser) parse() ([]RelativeTime, error) {
+	words := strings.Fields(strings.ToLower(strings.ReplaceAll(p.input, ",", " ")))
+	if len(words) == 0 {
+		return nil, p.errorf("empty input")
+	}
+	if len(words) == 1 && words[0] == "now" {
+		return []RelativeTime{p.instant(p.now, "now")}, nil
+	}
+	if alts, ok, err := p.parseBoundary(words); ok {
+		return alts, err
+	}
+	if alts, ok, err := p.parsePeriod(words); ok {
+		return alts, err
+	}
+
+	words, clocks, err := p.extractClock(words)
+	if err != nil {
+		return nil, err
+	}
+
+	// Offsets are instants, unless the offset in days has the time of day, i.e. "in 2 days at noon".
+	if t, desc, calendar, ok := p.parseOffset(words); ok {
+		switch {
+		case clocks == nil:
+			return []RelativeTime{p.instant(t, desc)}, nil
+		case !calendar:
+			return nil, p.errorf("%s cannot have the time of day", strings.Join(words, " "))
+		}
+	}
+
+	var days []dayExpr
+	if len(words) == 0 {
+		if clocks == nil {
+			return nil, p.errorf("unrecognized expression")
+		}
+		days = []dayExpr{{day: p.midnight(p.now), desc: "today"}}
+	} else {
+		if days, err = p.parseDay(words); err != nil {
+			return nil, err
+		}
+	}
+
+	var alts []RelativeTime
+	if clocks == nil {
+		for _, d := range days {
+			alts = append(alts, RelativeTime{
+				Start:          d.day,
+				End:            p.midnight(d.day.AddDate(0, 0, 1)),
+				Interpretation: d.desc,
+			})
+		}
+		return alts, nil
+	}
+	for _, d := range days {
+		for _, c := range clocks {
+			at, err := p.at(d.day, c, d.desc+" at "+c.desc)
+			if err != nil {
+				return nil, err
+			}
+			alts = append(alts, at...)
+		}
+	}
+	// The time of day alone is ambiguous if it has passed already.
+	if len(words) == 0 && len(clocks) == 1 && len(alts) == 1 && alts[0].Start.Before(p.now) {
+		c := clocks[0]
+		tomorrow, err := p.at(p.midnight(p.now).AddDate(0, 0, 1), c, "tomorrow at "+c.desc)
+		if err != nil {
+			return nil, err
+		}
+		alts[0].Interpretation = "today at " + c.desc + ", which has passed"
+		alts = append(alts, tomorrow...)
+	}
+	return alts, nil
+}
+
+// at returns the instants of the time of day on the day. The time of day skipped by the daylight saving
+// time change is an error, and the time of day repeated by it has two instants, i.e. "02:30 CEST" and "02:30 CET".
+func (p *relativeParser) at(day time.Time, c clockExpr, desc string) ([]RelativeTime, error) {
+	t := time.Date(day.Year(), day.Month(), day.Day(), c.hour, c.minute, 0, 0, p.now.Location())
+	// The offsets of the DST changes are months apart, thus the offsets half a day around
+	// are the ones before and after the change.
+	var alts []RelativeTime
+	for _, probe := range []time.Duration{-12 * time.Hour, 12 * time.Hour} {
+		_, offset := t.Add(probe).Zone()
+		_, toffset := t.Zone()
+		at := t.Add(time.Duration(toffset-offset) * time.Second)
+		if at.Hour() != c.hour || at.Minute() != c.minute || at.Day() != day.Day() {
+			continue
+		}
+		if len(alts) == 1 && !alts[0].Start.Equal(at) {
+			alts[0].Interpretation += " " + alts[0].Start.Format("MST")
+			alts = append(alts, p.instant(at, desc+" "+at.Format("MST")))
+		} else if len(alts) == 0 {
+			alts = append(alts, p.instant(at, desc))
+		}
+	}
+	if len(alts) == 0 {
+		return nil, p.errorf("%s does not exist, it is skipped by the daylight saving time change", desc)
+	}
+	return alts, nil
+}
+
+func (p *relativeParser) instant(t time.Time, desc string) RelativeTime {
+	return RelativeTime{Start: t, End: t, Interpretation: desc}
+}
+
+func (p *relativeParser) midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, p.now.Location())
+}
+
+// parseBoundary parses the "start of" and "end of" expressions.
+func (p *relativeParser) parseBoundary(words []string) ([]RelativeTime, bool, error) {
+	if len(words) < 3 || words[1] != "of" {
+		return nil, false, nil
+	}
+	var end bool
+	switch words[0] {
+	case "start", "beginning":
+	case "end":
+		end = true
+	default:
+		return nil, false, nil
+	}
+	rest := words[2:]
+	if rest[0] == "the" {
+		rest = rest[1:]
+	}
+	if len(rest) == 1 {
+		switch rest[0] {
+		case "day", "week", "month", "year":
+			rest = []string{"this", rest[0]}
+		}
+	}
+
+	var start, stop time.Time
+	var desc string
+	if alts, ok, err := p.parsePeriod(rest); ok {
+		if err != nil {
+			return nil, true, err
+		}
+		start, stop, desc = alts[0].Start, alts[0].End, alts[0].Interpretation
+	} else {
+		days, err := p.parseDay(rest)
+		if err != nil {
+			return nil, true, err
+		}
+		if len(days) > 1 {
+			var alts []RelativeTime
+			for _, d := range days {
+				t := d.day
+				if end {
+					t = p.midnight(t.AddDate(0, 0, 1))
+				}
+				alts = append(alts, p.instant(t, words[0]+" of "+d.desc))
+			}
+			return alts, true, nil
+		}
+		start, stop, desc = days[0].day, p.midnight(days[0].day.AddDate(0, 0, 1)), days[0].desc
+	}
+	if end {
+		return []RelativeTime{p.instant(stop, "end of "+desc)}, true, nil
+	}
+	return []RelativeTime{p.instant(start, words[0]+" of "+desc)}, true, nil
+}
+
+// parsePeriod parses the "this", "next" and "last" week, month and year expressions.
+func (p *relativeParser) parsePeriod(words []string) ([]RelativeTime, bool, error) {
+	if len(words) != 2 {
+		return nil, false, nil
+	}
+	var n int
+	switch words[0] {
+	case "this":
+	case "next":
+		n = 1
+	case "last", "previous":
+		n = -1
+	default:
+		return nil, false, nil
+	}
+
+	today := p.midnight(p.now)
+	var start, end time.Time
+	switch words[1] {
+	case "day":
+		start = today.AddDate(0, 0, n)
+		end = p.midnight(start.AddDate(0, 0, 1))
+	case "week":
+		monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+		start = p.midnight(monday.AddDate(0, 0, 7*n))
+		end = p.midnight(start.AddDate(0, 0, 7))
+	case "month":
+		start = time.Date(today.Year(), today.Month()+time.Month(n), 1, 0, 0, 0, 0, p.now.Location())
+		end = time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, p.now.Location())
+	case "year":
+		start = time.Date(today.Year()+n, time.January, 1, 0, 0, 0, 0, p.now.Location())
+		end = time.Date(start.Year()+1, time.January, 1, 0, 0, 0, 0, p.now.Location())
+	default:
+		return nil, false, nil
+	}
+	desc := fmt.Sprintf("%s %s (%s - %s)", words[0], words[1], start.Format("2006-01-02"), end.AddDate(0, 0, -1).Format("2006-01-02"))
+	return []RelativeTime{{Start: start, End: end, Interpretation: desc}}, true, nil
+}
+
+// parseOffset parses the "in 3 hours", "2 days ago" and "10 minutes from now" expressions.
+// The calendar result reports whether the offset is in days or longer units.
+func (p *relativeParser) parseOffset(words []string) (t time.Time, desc string, calendar, ok bool) {
+	desc = strings.Join(words, " ")
+	var sign int
+	switch {
+	case len(words) == 3 && words[0] == "in":
+		sign, words = 1, words[1:]
+	case len(words) == 3 && words[2] == "ago":
+		sign, words = -1, words[:2]
+	case len(words) == 4 && words[2] == "from" && words[3] == "now":
+		sign, words = 1, words[:2]
+	default:
+		return time.Time{}, "", false, false
+	}
+
+	var n int
+	switch words[0] {
+	case "a", "an", "one":
+		n = 1
+	default:
+		v, err := strconv.Atoi(words[0])
+		if err != nil || v < 0 {
+			return time.Time{}, "", false, false
+		}
+		n = v
+	}
+	unit := strings.TrimSuffix(words[1], "s")
+	n *= sign
+
+	switch unit {
+	case "second", "sec":
+		t = p.now.Add(time.Duration(n) * time.Second)
+	case "minute", "min":
+		t = p.now.Add(time.Duration(n) * time.Minute)
+	case "hour", "hr":
+		t = p.now.Add(time.Duration(n) * time.Hour)
+	case "day":
+		t, calendar = p.now.AddDate(0, 0, n), true
+	case "week":
+		t, calendar = p.now.AddDate(0, 0, 7*n), true
+	case "month":
+		t, calendar = addMonths(p.now, n), true
+	case "year":
+		t, calendar = addMonths(p.now, 12*n), true
+	default:
+		return time.Time{}, "", false, false
+	}
+	return t, fmt.Sprintf("%s (%s)", desc, t.Format("2006-01-02 15:04 MST")), calendar, true
+}
+
+// addMonths adds the months to t, clamping the day to the last day of the target month,
+// i.e. January 31 plus a month is the last day of February, rather than normalized to March.
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	day := t.Day()
+	if last := daysIn(first.Month(), first.Year()); day > last {
+		day = last
+	}
+	return time.Date(first.Year(), first.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// parseDay parses the day expressions.
+func (p *relativeParser) parseDay(words []string) ([]dayExpr, error) {
+	today := p.midnight(p.now)
+	day := func(t time.Time, desc string) dayExpr {
+		layout := "Monday 2006-01-02"
+		if strings.Contains(desc, t.Weekday().String()) {
+			layout = "2006-01-02"
+		}
+		return dayExpr{day: p.midnight(t), desc: fmt.Sprintf("%s (%s)", desc, t.Format(layout))}
+	}
+
+	if len(words) == 1 {
+		switch words[0] {
+		case "today":
+			return []dayExpr{day(today, "today")}, nil
+		case "tomorrow":
+			return []dayExpr{day(today.AddDate(0, 0, 1), "tomorrow")}, nil
+		case "yesterday":
+			return []dayExpr{day(today.AddDate(0, 0, -1), "yesterday")}, nil
+		}
+		t, err := time.ParseInLocation("2006-01-02", words[0], p.now.Location())
+		if err == nil {
+			return []dayExpr{{day: t, desc: t.Format("Monday 2006-01-02")}}, nil
+		}
+		var perr *time.ParseError
+		if errors.As(err, &perr) && perr.Message != "" {
+			// The date is well-formed, but out of range, i.e. "2024-02-30".
+			return nil, p.errorf("invalid date %s%s", words[0], perr.Message)
+		}
+	}
+
+	// In 2 days, 3 weeks ago.
+	if t, _, calendar, ok := p.parseOffset(words); ok && calendar {
+		return []dayExpr{day(t, strings.Join(words, " "))}, nil
+	}
+
+	modifier := "this"
+	if len(words) == 2 {
+		modifier, words = words[0], words[1:]
+	}
+	if len(words) != 1 {
+		return nil, p.errorf("unrecognized expression")
+	}
+	wd, ok := parseWeekday(words[0])
+	if !ok {
+		return nil, p.errorf("unrecognized expression")
+	}
+	name := wd.String()
+	ahead := (int(wd) - int(today.Weekday()) + 7) % 7
+
+	switch modifier {
+	case "this":
+		if ahead == 0 {
+			return []dayExpr{
+				day(today, name+" today"),
+				day(today.AddDate(0, 0, 7), name+" of next week"),
+			}, nil
+		}
+		return []dayExpr{day(today.AddDate(0, 0, ahead), "the coming "+name)}, nil
+	case "next":
+		if ahead == 0 {
+			ahead = 7
+		}
+		upcoming := today.AddDate(0, 0, ahead)
+		daysToSunday := (7 - int(today.Weekday())) % 7
+		if ahead <= daysToSunday {
+			// The upcoming day is in the current week, thus "next" may mean the following one.
+			return []dayExpr{
+				day(upcoming, name+" of this week"),
+				day(upcoming.AddDate(0, 0, 7), name+" of next week"),
+			}, nil
+		}
+		return []dayExpr{day(upcoming, name+" of next week")}, nil
+	case "last", "previous":
+		behind := (int(today.Weekday()) - int(wd) + 7) % 7
+		if behind == 0 {
+			behind = 7
+		}
+		return []dayExpr{day(today.AddDate(0, 0, -behind), "last "+name)}, nil
+	}
+	return nil, p.errorf("unrecognized expression")
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		name := strings.ToLower(wd.String())
+		if s == name || s == name[:3] {
+			return wd, true
+		}
+	}
+	return 0, false
+}
+
+// extractClock removes the time of day from the words.
+// The time of day is either at the start or at the end of the expression, optionally preceded by "at".
+// It returns two alternatives for the ambiguous hours, i.e. "at 9".
+func (p *relativeParser) extractClock(words []string) ([]string, []clockExpr, error) {
+	try := func(ws []string) ([]clockExpr, bool, error) {
+		if len(ws) > 0 && ws[0] == "at" {
+			ws = ws[1:]
+		}
+		switch len(ws) {
+		case 1:
+			return p.parseClock(ws[0], "")
+		case 2:
+			if ws[1] == "am" || ws[1] == "pm" {
+				return p.parseClock(ws[0], ws[1])
+			}
+		}
+		return nil, false, nil
+	}
+
+	// The longest suffix or prefix wins, i.e. "9 pm" rather than "pm".
+	for n := 3; n >= 1; n-- {
+		if n > len(words) {
+			continue
+		}
+		if clocks, ok, err := try(words[len(words)-n:]); ok || err != nil {
+			return words[:len(words)-n], clocks, err
+		}
+		if n < len(words) {
+			if clocks, ok, err := try(words[:n]); ok || err != nil {
+				return words[n:], clocks, err
+			}
+		}
+	}
+	return words, nil, nil
+}
+
+// parseClock parses the time of day, i.e. "9am", "9:30", "21:00", "noon" or "midnight".
+func (p *relativeParser) parseClock(s, period string) ([]clockExpr, bool, error) {
+	switch s {
+	case "noon", "midday":
+		return []clockExpr{{hour: 12, desc: "12:00"}}, period == "", nil
+	case "midnight":
+		return []clockExpr{{hour: 0, desc: "00:00"}}, period == "", nil
+	}
+	if period == "" {
+		switch {
+		case strings.HasSuffix(s, "am"):
+			s, period = strings.TrimSuffix(s, "am"), "am"
+		case strings.HasSuffix(s, "pm"):
+			s, period = strings.TrimSuffix(s, "pm"), "pm"
+		}
+	}
+
+	hh, mm, hasMinutes := strings.Cut(s, ":")
+	hour, ok := parseDigits(hh)
+	if !ok || len(hh) > 2 {
+		return nil, false, nil
+	}
+	var minute int
+	if hasMinutes {
+		if minute, ok = parseDigits(mm); !ok || len(mm) != 2 {
+			return nil, false, nil
+		}
+	} else if period == "" {
+		// A bare number is a time of day only after "at", which is checked by the caller.
+		return p.parseBareHour(hour)
+	}
+	if minute > 59 {
+		return nil, true, p.errorf("minute out of range")
+	}
+
+	switch period {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return nil, true, p.errorf("hour out of range")
+		}
+		hour %= 12
+		if period == "pm" {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return nil, true, p.errorf("hour out of range")
+		}
+		if hour >= 1 && hour <= 12 && len(hh) == 1 {
+			// 9:30 could be either in the morning or in the evening.
+			return []clockExpr{
+				{hour: hour % 12, minute: minute, desc: fmt.Sprintf("%02d:%02d", hour%12, minute)},
+				{hour: hour%12 + 12, minute: minute, desc: fmt.Sprintf("%02d:%02d", hour%12+12, minute)},
+			}, true, nil
+		}
+	}
+	return []clockExpr{{hour: hour, minute: minute, desc: fmt.Sprintf("%02d:%02d", hour, minute)}}, true, nil
+}
+
+// parseBareHour parses the hour without minutes and period, which is always ambiguous, i.e. "at 9".
+func (p *relativeParser) parseBareHour(hour int) ([]clockExpr, bool, error) {
+	if !strings.Contains(" "+strings.ToLower(p.input)+" ", " at ") {
+		return nil, false, nil
+	}
+	if hour > 23 {
+		return nil, true, p.errorf("hour out of range")
+	}
+	if hour == 0 || hour > 12 {
+		return []clockExpr{{hour: hour, desc: fmt.Sprintf("%02d:00", hour)}}, true, nil
+	}
+	return []clockExpr{
+		{hour: hour % 12, desc: fmt.Sprintf("%02d:00", hour%12)},
+		{hour: hour%12 + 12, desc: fmt.Sprintf("%02d:00", hour%12+12)},
+	}, true, nil
+}
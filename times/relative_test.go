@@ -0,0 +1,190 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fixedClock is a Clock stopped at the given time.
+type fixedClock struct {
+	ZonedClock
+	now time.Time
+}
+
+func newFixedClock(now time.Time) *fixedClock {
+	return &fixedClock{ZonedClock: ZonedClock{loc: now.Location()}, now: now}
+}
+
+func (c *fixedClock) Now() time.Time                  { return c.now }
+func (c *fixedClock) Unix() int64                     { return c.now.Unix() }
+func (c *fixedClock) UnixNano() int64                 { return c.now.UnixNano() }
+func (c *fixedClock) Since(t time.Time) time.Duration { return c.now.Sub(t) }
+func (c *fixedClock) Until(t time.Time) time.Duration { return t.Sub(c.now) }
+
+func TestParseRelative(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	date := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2023, month, day, hour, minute, 0, 0, berlin)
+	}
+	// Wednesday.
+	clock := newFixedClock(date(time.July, 5, 10, 30))
+
+	for _, test := range []struct {
+		input     string
+		wantStart time.Time
+		wantEnd   time.Time // zero for instants
+	}{
+		{input: "now", wantStart: date(time.July, 5, 10, 30)},
+		{input: "in 3 hours", wantStart: date(time.July, 5, 13, 30)},
+		{input: "in an hour", wantStart: date(time.July, 5, 11, 30)},
+		{input: "2 days ago", wantStart: date(time.July, 3, 10, 30)},
+		{input: "10 minutes from now", wantStart: date(time.July, 5, 10, 40)},
+		{input: "tomorrow", wantStart: date(time.July, 6, 0, 0), wantEnd: date(time.July, 7, 0, 0)},
+		{input: "Tomorrow 9am", wantStart: date(time.July, 6, 9, 0)},
+		{input: "9:15 pm tomorrow", wantStart: date(time.July, 6, 21, 15)},
+		{input: "yesterday at noon", wantStart: date(time.July, 4, 12, 0)},
+		{input: "friday", wantStart: date(time.July, 7, 0, 0), wantEnd: date(time.July, 8, 0, 0)},
+		{input: "next monday at 17:00", wantStart: date(time.July, 10, 17, 0)},
+		{input: "last friday", wantStart: date(time.June, 30, 0, 0), wantEnd: date(time.July, 1, 0, 0)},
+		{input: "in 2 days at midnight", wantStart: date(time.July, 7, 0, 0)},
+		{input: "2023-08-01 at 08:00", wantStart: date(time.August, 1, 8, 0)},
+		{input: "next week", wantStart: date(time.July, 10, 0, 0), wantEnd: date(time.July, 17, 0, 0)},
+		{input: "this month", wantStart: date(time.July, 1, 0, 0), wantEnd: date(time.August, 1, 0, 0)},
+		{input: "end of month", wantStart: date(time.August, 1, 0, 0)},
+		{input: "start of next week", wantStart: date(time.July, 10, 0, 0)},
+		{input: "end of the day", wantStart: date(time.July, 6, 0, 0)},
+		{input: "23:00", wantStart: date(time.July, 5, 23, 0)},
+	} {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := ParseRelative(test.input, clock)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			wantEnd := test.wantEnd
+			if wantEnd.IsZero() {
+				wantEnd = test.wantStart
+			}
+			if !got.Start.Equal(test.wantStart) || !got.End.Equal(wantEnd) {
+				t.Errorf("got [%v, %v), want [%v, %v)", got.Start, got.End, test.wantStart, wantEnd)
+			}
+			if got.Interpretation == "" {
+				t.Errorf("empty interpretation")
+			}
+		})
+	}
+}
+
+func TestParseRelativeAmbiguous(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Wednesday.
+	clock := newFixedClock(time.Date(2023, time.July, 5, 10, 30, 0, 0, berlin))
+
+	for _, test := range []struct {
+		input    string
+		wantAlts int
+	}{
+		{input: "next friday", wantAlts: 2},
+		{input: "wednesday", wantAlts: 2},
+		{input: "tomorrow at 9", wantAlts: 2},
+		{input: "9am", wantAlts: 2},
+		{input: "9:30", wantAlts: 2},
+	} {
+		t.Run(test.input, func(t *testing.T) {
+			_, err := ParseRelative(test.input, clock)
+			var aerr *AmbiguousTimeError
+			if !errors.As(err, &aerr) {
+				t.Fatalf("got error %v, want *AmbiguousTimeError", err)
+			}
+			if len(aerr.Alternatives) != test.wantAlts {
+				t.Errorf("got %d alternatives, want %d", len(aerr.Alternatives), test.wantAlts)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "someday", "in 3 hours at 9am", "tomorrow at 25:00", "13pm"} {
+		if got, err := ParseRelative(input, clock); err == nil {
+			t.Errorf("%q: got %v, want error", input, got)
+		}
+	}
+	if _, err := ParseRelative("2024-02-30", clock); err == nil || !strings.Contains(err.Error(), "invalid date") {
+		t.Errorf("got error %v, want invalid date", err)
+	}
+}
+
+func TestParseRelativeCalendar(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		now   time.Time
+		input string
+		want  time.Time
+	}{
+		{now: time.Date(2024, time.January, 31, 10, 0, 0, 0, berlin), input: "in 1 month", want: time.Date(2024, time.February, 29, 10, 0, 0, 0, berlin)},
+		{now: time.Date(2023, time.March, 31, 10, 0, 0, 0, berlin), input: "a month ago", want: time.Date(2023, time.February, 28, 10, 0, 0, 0, berlin)},
+		{now: time.Date(2024, time.February, 29, 10, 0, 0, 0, berlin), input: "in a year", want: time.Date(2025, time.February, 28, 10, 0, 0, 0, berlin)},
+		{now: time.Date(2024, time.January, 31, 10, 0, 0, 0, berlin), input: "in 1 month at noon", want: time.Date(2024, time.February, 29, 12, 0, 0, 0, berlin)},
+	} {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := ParseRelative(test.input, newFixedClock(test.now))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Start.Equal(test.want) {
+				t.Errorf("got %v, want %v", got.Start, test.want)
+			}
+		})
+	}
+}
+
+func TestParseRelativeDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The clocks skip from 02:00 to 03:00 on 2023-03-26.
+	clock := newFixedClock(time.Date(2023, time.March, 25, 10, 0, 0, 0, berlin))
+	if got, err := ParseRelative("tomorrow 2:30am", clock); err == nil {
+		t.Errorf("skipped time: got %v, want error", got)
+	}
+	if got, err := ParseRelative("tomorrow 3:30am", clock); err != nil || !got.Start.Equal(time.Date(2023, time.March, 26, 1, 30, 0, 0, time.UTC)) {
+		t.Errorf("got %v, %v, want 01:30 UTC", got.Start, err)
+	}
+
+	// The clocks turn back from 03:00 to 02:00 on 2023-10-29.
+	clock = newFixedClock(time.Date(2023, time.October, 28, 10, 0, 0, 0, berlin))
+	_, err = ParseRelative("tomorrow 2:30am", clock)
+	var aerr *AmbiguousTimeError
+	if !errors.As(err, &aerr) {
+		t.Fatalf("repeated time: got error %v, want *AmbiguousTimeError", err)
+	}
+	if len(aerr.Alternatives) != 2 ||
+		!aerr.Alternatives[0].Start.Equal(time.Date(2023, time.October, 29, 0, 30, 0, 0, time.UTC)) ||
+		!aerr.Alternatives[1].Start.Equal(time.Date(2023, time.October, 29, 1, 30, 0, 0, time.UTC)) {
+		t.Errorf("got alternatives %v, want 00:30 and 01:30 UTC", aerr.Alternatives)
+	}
+}
@@ -0,0 +1,436 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// ErrNoSNTPServers is returned when the SNTPClient has no servers to query.
+var ErrNoSNTPServers = errors.New("no SNTP servers configured")
+
+const (
+	// sntpPacketSize is the size of the SNTP packet without the authenticator.
+	sntpPacketSize = 48
+	// ntpEpochOffset is the number of seconds between the NTP epoch (1900) and the Unix epoch (1970).
+	ntpEpochOffset = 2208988800
+	// sntpDefaultPort is the default port of the NTP servers.
+	sntpDefaultPort = "123"
+)
+
+// SNTPClient measures the offset of the local clock with the SNTP (RFC 4330) servers.
+type SNTPClient struct {
+	servers []string
+	timeout time.Duration
+}
+
+// NewSNTPClient creates a new SNTPClient querying given servers, i.e. "pool.ntp.org" or "10.0.0.1:123".
+// The default port is 123 and the default timeout of a query is 5 seconds.
+func NewSNTPClient(servers ...string) *SNTPClient {
+	return &SNTPClient{servers: servers, timeout: 5 * time.Second}
+}
+
+// WithTimeout sets the timeout of a single query,
+// and returns the pointer to this client, to allow for chaining.
+func (c *SNTPClient) WithTimeout(timeout time.Duration) *SNTPClient {
+	c.timeout = timeout
+	return c
+}
+
+// SNTPResponse is the result of the SNTP query.
+type SNTPResponse struct {
+	// Server is the queried server.
+	Server string
+	// Time is the server time, when the response was sent.
+	Time time.Time
+	// Offset is the offset of the local clock, which has to be added to the local time.
+	Offset time.Duration
+	// Delay is the round-trip delay of the query.
+	Delay time.Duration
+	// Stratum is the stratum of the server, 1 for primary servers.
+	Stratum uint8
+}
+
+// SNTPKissError is returned when the server responds with the kiss-o'-death packet.
+// The client should stop querying the server, if the code is "DENY" or "RSTR",
+// and reduce the query rate, if the code is "RATE".
+type SNTPKissError struct {
+	Server string // The queried server.
+	Code   string // The kiss code, i.e. "RATE".
+}
+
+func (e *SNTPKissError) Error() string {
+	return fmt.Sprintf("SNTP server %s sent kiss-o'-death %q", e.Server, e.Code)
+}
+
+// Measure queries all the servers and returns the response with the lowest round-trip delay,
+// as it gives the most accurate offset. An error is returned only if all the queries fail.
+func (c *SNTPClient) Measure(ctx context.Context) (*SNTPResponse, error) {
+	if len(c.servers) == 0 {
+		return nil, ErrNoSNTPServers
+	}
+
+	type result struct {
+		resp *SNTPResponse
+		err  error
+	}
+	results := make([]result, len(c.servers))
+	var wg sync.WaitGroup
+	for i, server := range c.servers {
+		wg.Add(1)
+		go func(i int, server string) {
+			defer wg.Done()
+			resp, err := c.Query(ctx, server)
+			results[i] = result{resp: resp, err: err}
+		}(i, server)
+	}
+	wg.Wait()
+
+	var best *SNTPResponse
+	var errs []error
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		if best == nil || r.resp.Delay < best.Delay {
+			best = r.resp
+		}
+	}
+	if best == nil {
+		return nil, errors.Join(errs...)
+	}
+	return best, nil
+}
+
+// Query queries the single server.
+func (c *SNTPClient) Query(ctx context.Context, server string) (*SNTPResponse, error) {
+	addr := server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		addr = net.JoinHostPort(server, sntpDefaultPort)
+	}
+
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+	// Unblock the read if the context is canceled.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.SetDeadline(time.Now())
+		case <-stop:
+		}
+	}()
+
+	// LI = 0, VN = 4, Mode = 3 (client).
+	req := make([]byte, sntpPacketSize)
+	req[0] = 0<<6 | 4<<3 | 3
+	t1 := time.Now()
+	binary.BigEndian.PutUint64(req[40:], toNTPTime(t1))
+
+	if _, err = conn.Write(req); err != nil {
+		return nil, err
+	}
+
+	resp := make([]byte, 1024)
+	for {
+		n, err := conn.Read(resp)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+		// The destination time measured with the monotonic clock is immune to the clock jumps.
+		t4 := t1.Add(time.Since(t1))
+		if n < sntpPacketSize {
+			continue
+		}
+		// The originate timestamp must echo our transmit timestamp,
+		// otherwise the packet is a stale or spoofed response.
+		if binary.BigEndian.Uint64(resp[24:]) != binary.BigEndian.Uint64(req[40:]) {
+			continue
+		}
+		return parseSNTPResponse(server, resp[:n], t1, t4)
+	}
+}
+
+func parseSNTPResponse(server string, p []byte, t1, t4 time.Time) (*SNTPResponse, error) {
+	li, mode, stratum := p[0]>>6, p[0]&0x7, p[1]
+	if mode != 4 {
+		return nil, fmt.Errorf("SNTP server %s sent unexpected mode %d", server, mode)
+	}
+	if stratum == 0 {
+		return nil, &SNTPKissError{Server: server, Code: string(p[12:16])}
+	}
+	if li == 3 {
+		return nil, fmt.Errorf("SNTP server %s is not synchronized", server)
+	}
+	if stratum > 15 {
+		return nil, fmt.Errorf("SNTP server %s sent invalid stratum %d", server, stratum)
+	}
+	transmit := binary.BigEndian.Uint64(p[40:])
+	if transmit == 0 {
+		return nil, fmt.Errorf("SNTP server %s sent zero transmit time", server)
+	}
+
+	t2 := fromNTPTime(binary.BigEndian.Uint64(p[32:]))
+	t3 := fromNTPTime(transmit)
+	// RFC 4330, section 5.
+	//	d = (T4 - T1) - (T3 - T2)
+	//	t = ((T2 - T1) + (T3 - T4)) / 2
+	delay := t4.Sub(t1) - t3.Sub(t2)
+	if delay < 0 {
+		delay = 0
+	}
+	offset := (t2.Sub(t1.Round(0)) + t3.Sub(t4.Round(0))) / 2
+	return &SNTPResponse{
+		Server:  server,
+		Time:    t3,
+		Offset:  offset,
+		Delay:   delay,
+		Stratum: stratum,
+	}, nil
+}
+
+// toNTPTime converts t to the NTP timestamp, 32 bit seconds since 1900 and 32 bit fraction.
+func toNTPTime(t time.Time) uint64 {
+	sec := uint64(t.Unix() + ntpEpochOffset)
+	frac := uint64(t.Nanosecond()) << 32 / uint64(time.Second)
+	return sec<<32 | frac
+}
+
+// fromNTPTime converts the NTP timestamp to the time.
+// The timestamps with the most significant bit unset are in the NTP era 1, from 2036.
+func fromNTPTime(ts uint64) time.Time {
+	sec, frac := int64(ts>>32), int64(ts&0xffffffff)
+	if sec&0x80000000 == 0 {
+		sec += 1 << 32
+	}
+	nsec := frac * int64(time.Second) >> 32
+	return time.Unix(sec-ntpEpochOffset, nsec)
+}
+
+var _ Clock = (*OffsetClock)(nil)
+
+// OffsetClock is a Clock that corrects the local time with the offset measured by the SNTPClient.
+// The offset is re-measured periodically, and the changes of the offset are slewed,
+// which means that the time is sped up or slowed down, rather than jumping,
+// thus it never goes backwards.
+type OffsetClock struct {
+	loc      *time.Location
+	client   *SNTPClient
+	interval time.Duration
+	slewRate float64
+	now      func() time.Time
+
+	mu      sync.Mutex
+	from    time.Duration // The offset when the slew started.
+	to      time.Duration // The measured offset.
+	since   time.Time     // The start of the slew.
+	lastErr error
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewOffsetClock creates a new OffsetClock in given location.
+// The default re-measurement interval is 15 minutes,
+// and the default slew rate is 500 ppm, which corrects 0.5ms per second.
+func NewOffsetClock(loc *time.Location, client *SNTPClient) *OffsetClock {
+	return &OffsetClock{
+		loc:      loc,
+		client:   client,
+		interval: 15 * time.Minute,
+		slewRate: 500e-6,
+		now:      time.Now,
+	}
+}
+
+// WithInterval sets the interval of the offset re-measurement, which must be positive,
+// and returns the pointer to this clock, to allow for chaining.
+func (c *OffsetClock) WithInterval(interval time.Duration) *OffsetClock {
+	c.interval = interval
+	return c
+}
+
+// WithSlewRate sets the maximum rate of the offset correction, in seconds per second.
+// The rate must be less than 1, for the time to never go backwards.
+// It returns the pointer to this clock, to allow for chaining.
+func (c *OffsetClock) WithSlewRate(rate float64) *OffsetClock {
+	c.slewRate = rate
+	return c
+}
+
+// Start measures the offset, which is applied at once, as the clock was not used yet.
+// Then it starts re-measuring the offset in the background, until Stop is called.
+// The failed re-measurements keep the last offset, and are reported by LastError.
+func (c *OffsetClock) Start(ctx context.Context) error {
+	if c.slewRate <= 0 || c.slewRate >= 1 {
+		return errors.New("slew rate must be in range (0, 1)")
+	}
+	if c.interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	resp, err := c.client.Measure(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stop != nil {
+		return errors.New("offset clock already started")
+	}
+	c.from, c.to, c.since = resp.Offset, resp.Offset, c.now()
+	c.stop, c.done = make(chan struct{}), make(chan struct{})
+	go c.run(c.stop, c.done)
+	return nil
+}
+
+// Stop stops the re-measurement of the offset. The last offset is kept.
+func (c *OffsetClock) Stop() {
+	c.mu.Lock()
+	stop, done := c.stop, c.done
+	c.stop, c.done = nil, nil
+	c.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+func (c *OffsetClock) run(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			resp, err := c.client.Measure(ctx)
+			c.mu.Lock()
+			c.lastErr = err
+			if err == nil {
+				c.adjust(resp.Offset)
+			}
+			c.mu.Unlock()
+		}
+	}
+}
+
+// adjust starts slewing towards the new offset, from the currently applied one.
+func (c *OffsetClock) adjust(offset time.Duration) {
+	now := c.now()
+	c.from, c.to, c.since = c.offsetAt(now), offset, now
+}
+
+// offsetAt returns the offset applied at the local time now.
+func (c *OffsetClock) offsetAt(now time.Time) time.Duration {
+	diff := c.to - c.from
+	if diff == 0 {
+		return c.to
+	}
+	maxStep := time.Duration(float64(now.Sub(c.since)) * c.slewRate)
+	switch {
+	case diff > maxStep:
+		return c.from + maxStep
+	case diff < -maxStep:
+		return c.from - maxStep
+	}
+	return c.to
+}
+
+// Offset returns the currently applied offset.
+func (c *OffsetClock) Offset() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.offsetAt(c.now())
+}
+
+// LastError returns the error of the last offset re-measurement, or nil if it succeeded.
+func (c *OffsetClock) LastError() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastErr
+}
+
+// Now returns the current corrected time.
+func (c *OffsetClock) Now() time.Time {
+	c.mu.Lock()
+	now := c.now()
+	offset := c.offsetAt(now)
+	c.mu.Unlock()
+	return now.Add(offset).In(c.loc)
+}
+
+// Unix returns the current time in unix format.
+func (c *OffsetClock) Unix() int64 {
+	return c.Now().Unix()
+}
+
+// UnixNano returns the current time in unix format.
+func (c *OffsetClock) UnixNano() int64 {
+	return c.Now().UnixNano()
+}
+
+// Location returns the current location.
+func (c *OffsetClock) Location() *time.Location {
+	return c.loc
+}
+
+// Since returns the time elapsed since t.
+func (c *OffsetClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Until returns the time until t.
+func (c *OffsetClock) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
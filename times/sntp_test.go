@@ -0,0 +1,181 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// startSNTPServer starts an in-process SNTP server, which clock is ahead of the local one by the offset.
+func startSNTPServer(t *testing.T, offset *atomic.Int64, stratum uint8) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if n < sntpPacketSize {
+				continue
+			}
+			now := time.Now().Add(time.Duration(offset.Load()))
+			resp := make([]byte, sntpPacketSize)
+			resp[0] = 0<<6 | 4<<3 | 4
+			resp[1] = stratum
+			if stratum == 0 {
+				copy(resp[12:16], "RATE")
+			}
+			copy(resp[24:32], buf[40:48])
+			binary.BigEndian.PutUint64(resp[32:], toNTPTime(now))
+			binary.BigEndian.PutUint64(resp[40:], toNTPTime(now))
+			_, _ = conn.WriteTo(resp, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestNTPTime(t *testing.T) {
+	tm := time.Date(2023, 7, 5, 12, 30, 15, 123456789, time.UTC)
+	got := fromNTPTime(toNTPTime(tm))
+	if d := got.Sub(tm); d < -time.Nanosecond || d > time.Nanosecond {
+		t.Errorf("round trip: got %v, want %v", got, tm)
+	}
+
+	// The NTP era 1 starts on 2036-02-07.
+	era1 := time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := fromNTPTime(toNTPTime(era1)); !got.Equal(era1) {
+		t.Errorf("era 1: got %v, want %v", got, era1)
+	}
+}
+
+func TestSNTPClient_Measure(t *testing.T) {
+	var offset atomic.Int64
+	offset.Store(int64(2 * time.Second))
+	addr := startSNTPServer(t, &offset, 2)
+
+	c := NewSNTPClient(addr, "127.0.0.1:1").WithTimeout(time.Second)
+	resp, err := c.Measure(context.Background())
+	if err != nil {
+		t.Fatalf("Measure: %v", err)
+	}
+	if d := resp.Offset - 2*time.Second; d < -50*time.Millisecond || d > 50*time.Millisecond {
+		t.Errorf("offset: got %v, want about 2s", resp.Offset)
+	}
+	if resp.Server != addr || resp.Stratum != 2 {
+		t.Errorf("response: got server %q stratum %d", resp.Server, resp.Stratum)
+	}
+
+	if _, err = NewSNTPClient().Measure(context.Background()); !errors.Is(err, ErrNoSNTPServers) {
+		t.Errorf("no servers: got %v", err)
+	}
+}
+
+func TestSNTPClient_Kiss(t *testing.T) {
+	var offset atomic.Int64
+	addr := startSNTPServer(t, &offset, 0)
+
+	_, err := NewSNTPClient(addr).WithTimeout(time.Second).Query(context.Background(), addr)
+	var kiss *SNTPKissError
+	if !errors.As(err, &kiss) || kiss.Code != "RATE" {
+		t.Fatalf("got %v, want kiss-o'-death RATE", err)
+	}
+}
+
+func TestOffsetClock(t *testing.T) {
+	var offset atomic.Int64
+	offset.Store(int64(time.Second))
+	addr := startSNTPServer(t, &offset, 1)
+
+	c := NewOffsetClock(time.UTC, NewSNTPClient(addr).WithTimeout(time.Second)).
+		WithInterval(20 * time.Millisecond).
+		WithSlewRate(0.5)
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer c.Stop()
+
+	if d := c.Now().Sub(time.Now()) - time.Second; d < -50*time.Millisecond || d > 50*time.Millisecond {
+		t.Errorf("initial offset: got %v, want about 1s", c.Offset())
+	}
+
+	// The new offset is slewed, and the time never goes backwards.
+	offset.Store(int64(900 * time.Millisecond))
+	prev := c.Now()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		now := c.Now()
+		if now.Before(prev) {
+			t.Fatalf("time went backwards: %v before %v", now, prev)
+		}
+		prev = now
+		if o := c.Offset(); o < 950*time.Millisecond {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if o := c.Offset(); o > 950*time.Millisecond {
+		t.Errorf("offset not slewed: got %v", o)
+	}
+	if err := c.LastError(); err != nil {
+		t.Errorf("LastError: %v", err)
+	}
+}
+
+func TestOffsetClock_invalid(t *testing.T) {
+	for _, c := range []*OffsetClock{
+		NewOffsetClock(time.UTC, NewSNTPClient("127.0.0.1:1")).WithInterval(0),
+		NewOffsetClock(time.UTC, NewSNTPClient("127.0.0.1:1")).WithInterval(-time.Second),
+		NewOffsetClock(time.UTC, NewSNTPClient("127.0.0.1:1")).WithSlewRate(1),
+	} {
+		if err := c.Start(context.Background()); err == nil {
+			c.Stop()
+			t.Errorf("interval %v, slew rate %v: got nil error", c.interval, c.slewRate)
+		}
+	}
+}
+
+func TestOffsetClock_offsetAt(t *testing.T) {
+	start := time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC)
+	c := NewOffsetClock(time.UTC, nil)
+	c.now = func() time.Time { return start }
+	c.adjust(time.Second)
+
+	for _, tc := range []struct {
+		elapsed time.Duration
+		want    time.Duration
+	}{
+		{0, 0},
+		{time.Second, 500 * time.Microsecond},
+		{1000 * time.Second, 500 * time.Millisecond},
+		{3000 * time.Second, time.Second},
+	} {
+		if got := c.offsetAt(start.Add(tc.elapsed)); got != tc.want {
+			t.Errorf("after %v: got %v, want %v", tc.elapsed, got, tc.want)
+		}
+	}
+}
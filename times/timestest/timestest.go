@@ -0,0 +1,76 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package timestest provides the helpers overriding the local time zone detection of the times package in tests.
+// The overrides are process wide, thus the tests using them must not run in parallel.
+// Each override is restored when the test and its subtests complete.
+package timestest
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"testing"
+	"testing/fstest"
+
+	"github.com/blockysource/go-pkg/times/internal/tzlocal"
+)
+
+// SetLocal makes times.Local return the location of the zone name, i.e. "Asia/Tokyo", for the duration of the test.
+// The TZ environment variable and the system files are not consulted while the override is set.
+func SetLocal(t testing.TB, name string) {
+	t.Helper()
+	t.Cleanup(tzlocal.SetOverride(name))
+}
+
+// SetTZ sets the TZ environment variable for the duration of the test,
+// and resets the cached local location, so that times.Local detects it.
+func SetTZ(t testing.TB, value string) {
+	t.Helper()
+	t.Setenv("TZ", value)
+	tzlocal.ResetLocal()
+	t.Cleanup(tzlocal.ResetLocal)
+}
+
+// UnsetTZ unsets the TZ environment variable for the duration of the test,
+// so that times.Local falls back to the system files.
+func UnsetTZ(t testing.TB) {
+	t.Helper()
+	// Setenv registers the restoring of the previous value.
+	t.Setenv("TZ", "")
+	if err := os.Unsetenv("TZ"); err != nil {
+		t.Fatalf("failed to unset TZ: %v", err)
+	}
+	tzlocal.ResetLocal()
+	t.Cleanup(tzlocal.ResetLocal)
+}
+
+// SetFS makes the local zone detection read the system files, i.e. "etc/localtime", from the file system root,
+// for the duration of the test. It has no effect on Windows, where the zone is read from the registry.
+// The symbolic links are read with the ReadLink method, if the file system implements it,
+// otherwise they are the files with the fs.ModeSymlink mode, which content is the link target.
+func SetFS(t testing.TB, fsys fs.FS) {
+	t.Helper()
+	t.Cleanup(tzlocal.SetFS(fsys))
+}
+
+// LocaltimeFS returns the file system with "etc/localtime" linked to the zoneinfo file of the zone name.
+func LocaltimeFS(name string) fs.FS {
+	return fstest.MapFS{
+		"etc/localtime": &fstest.MapFile{
+			Data: []byte(path.Join("/usr/share/zoneinfo", name)),
+			Mode: fs.ModeSymlink | 0o777,
+		},
+	}
+}
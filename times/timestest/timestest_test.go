@@ -0,0 +1,81 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timestest_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/blockysource/go-pkg/times"
+	"github.com/blockysource/go-pkg/times/timestest"
+)
+
+func local(t *testing.T) string {
+	t.Helper()
+	loc, err := times.Local()
+	if err != nil {
+		t.Fatalf("Local: %v", err)
+	}
+	return loc.String()
+}
+
+func TestSetLocal(t *testing.T) {
+	before, beforeErr := times.Local()
+
+	t.Run("override", func(t *testing.T) {
+		timestest.SetLocal(t, "Asia/Tokyo")
+		if got := local(t); got != "Asia/Tokyo" {
+			t.Fatalf("got %q, want Asia/Tokyo", got)
+		}
+		t.Run("nested", func(t *testing.T) {
+			timestest.SetLocal(t, "America/Lima")
+			if got := local(t); got != "America/Lima" {
+				t.Fatalf("got %q, want America/Lima", got)
+			}
+		})
+		if got := local(t); got != "Asia/Tokyo" {
+			t.Fatalf("after nested: got %q, want Asia/Tokyo", got)
+		}
+	})
+
+	after, afterErr := times.Local()
+	if after.String() != before.String() || (afterErr == nil) != (beforeErr == nil) {
+		t.Fatalf("not restored: got %v, %v, want %v, %v", after, afterErr, before, beforeErr)
+	}
+}
+
+func TestSetTZ(t *testing.T) {
+	timestest.SetTZ(t, "Europe/Lisbon")
+	if got := local(t); got != "Europe/Lisbon" {
+		t.Fatalf("got %q, want Europe/Lisbon", got)
+	}
+}
+
+func TestSetFS(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the zone is read from the registry on windows")
+	}
+	timestest.UnsetTZ(t)
+
+	timestest.SetFS(t, timestest.LocaltimeFS("America/Argentina/Salta"))
+	if got := local(t); got != "America/Argentina/Salta" {
+		t.Fatalf("got %q, want America/Argentina/Salta", got)
+	}
+
+	timestest.SetFS(t, timestest.LocaltimeFS("Pacific/Fiji"))
+	if got := local(t); got != "Pacific/Fiji" {
+		t.Fatalf("got %q, want Pacific/Fiji", got)
+	}
+}
@@ -15,19 +15,12 @@
 package times
 
 import (
-	"sync"
 	"time"
 	_ "time/tzdata"
 
 	"github.com/blockysource/go-pkg/times/internal/tzlocal"
 )
 
-var localTZ struct {
-	once sync.Once
-	loc  *time.Location
-	err  error
-}
-
 // Local returns the local timezone with full name.
 // In comparison to time.Local, this function returns the full name of the timezone.
 // Example:
@@ -37,22 +30,26 @@ var localTZ struct {
 //
 // This is useful in cases where a session is timezone oriented.
 func Local() (*time.Location, error) {
-	localTZ.once.Do(func() {
-		tz, err := tzlocal.RuntimeTZ()
-		if err != nil {
-			localTZ.err = err
-			return
-		}
-		lTZ, err := time.LoadLocation(tz)
-		if err != nil {
-			localTZ.err = err
-		}
-		localTZ.loc = lTZ
-	})
-	if localTZ.err != nil {
-		return nil, localTZ.err
-	}
-	return localTZ.loc, nil
+	return tzlocal.LocalLocation()
+}
+
+// LocalSource is the strategy that detected the local timezone.
+type LocalSource = tzlocal.Source
+
+// The strategies detecting the local timezone, in the order they are tried.
+const (
+	// LocalSourceOverride is the timezone set by the timestest package.
+	LocalSourceOverride = tzlocal.SourceOverride
+	// LocalSourceEnv is the timezone of the TZ environment variable.
+	LocalSourceEnv = tzlocal.SourceEnv
+	// LocalSourceSystem is the timezone of the system settings, /etc/localtime or the Windows settings.
+	LocalSourceSystem = tzlocal.SourceSystem
+)
+
+// DetectLocal detects the name of the local timezone, like Local, and returns the strategy that found it.
+// In contrast to Local, the result is not cached.
+func DetectLocal() (string, LocalSource, error) {
+	return tzlocal.RuntimeTZSource()
 }
 
 // LoadLocation loads the timezone with the given name.
@@ -0,0 +1,81 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blockysource/go-pkg/times/internal/tzdata"
+)
+
+// The metazones and the names of the locales are downloaded from the CLDR repository.
+func main() {
+	locales := flag.String("locales", "en,de", "comma separated list of the CLDR locales to generate")
+	flag.Parse()
+
+	meta, err := tzdata.DownloadMetaZones()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	names := make(map[string]tzdata.TimeZoneNames)
+	for _, locale := range strings.Split(*locales, ",") {
+		locale = strings.TrimSpace(locale)
+		if names[locale], err = tzdata.DownloadTimeZoneNames(locale); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteString(licenseHeader)
+	out.WriteString("// Code generated by tznames/update_tznames.go DO NOT EDIT.\n")
+	out.WriteString("package tznames\n\n")
+	if err = tzdata.UpdateTimeZoneNames(&out, meta, names); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		panic(err)
+	}
+
+	path, _ := filepath.Abs("./names.go")
+	if err = os.WriteFile(path, src, 0644); err != nil {
+		panic(err)
+	}
+}
+
+const licenseHeader = `// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+`
@@ -0,0 +1,287 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tznames
+
+// Localized time zone names of the CLDR.
+//
+// The tables are a hand-curated subset of the CLDR data: the common metazones in the en and de locales.
+// They are not complete, go generate replaces them with the full tables generated from the CLDR.
+// The curated metazones are the current ones, without their history, thus their periods start at curatedFrom.
+
+// curatedFrom is the start of the curated metazone periods, 2023-01-01 00:00 UTC.
+// The names of the earlier instants fall back to the zone specific names, or to the localized GMT format.
+const curatedFrom = 1672531200
+
+// metazones maps the CLDR zone identifiers to their metazone periods.
+var metazones = map[string][]metazonePeriod{
+	"Africa/Abidjan":         {{"GMT", curatedFrom, 0}},
+	"Africa/Accra":           {{"GMT", curatedFrom, 0}},
+	"Africa/Addis_Ababa":     {{"Africa_Eastern", curatedFrom, 0}},
+	"Africa/Algiers":         {{"Europe_Central", curatedFrom, 0}},
+	"Africa/Cairo":           {{"Europe_Eastern", curatedFrom, 0}},
+	"Africa/Ceuta":           {{"Europe_Central", curatedFrom, 0}},
+	"Africa/Dakar":           {{"GMT", curatedFrom, 0}},
+	"Africa/Dar_es_Salaam":   {{"Africa_Eastern", curatedFrom, 0}},
+	"Africa/Harare":          {{"Africa_Central", curatedFrom, 0}},
+	"Africa/Johannesburg":    {{"Africa_Southern", curatedFrom, 0}},
+	"Africa/Kampala":         {{"Africa_Eastern", curatedFrom, 0}},
+	"Africa/Kinshasa":        {{"Africa_Western", curatedFrom, 0}},
+	"Africa/Lagos":           {{"Africa_Western", curatedFrom, 0}},
+	"Africa/Luanda":          {{"Africa_Western", curatedFrom, 0}},
+	"Africa/Lusaka":          {{"Africa_Central", curatedFrom, 0}},
+	"Africa/Maputo":          {{"Africa_Central", curatedFrom, 0}},
+	"Africa/Nairobi":         {{"Africa_Eastern", curatedFrom, 0}},
+	"Africa/Tripoli":         {{"Europe_Eastern", curatedFrom, 0}},
+	"Africa/Tunis":           {{"Europe_Central", curatedFrom, 0}},
+	"America/Adak":           {{"Hawaii_Aleutian", curatedFrom, 0}},
+	"America/Anchorage":      {{"Alaska", curatedFrom, 0}},
+	"America/Bahia":          {{"Brasilia", curatedFrom, 0}},
+	"America/Barbados":       {{"Atlantic", curatedFrom, 0}},
+	"America/Belem":          {{"Brasilia", curatedFrom, 0}},
+	"America/Belize":         {{"America_Central", curatedFrom, 0}},
+	"America/Bogota":         {{"Colombia", curatedFrom, 0}},
+	"America/Boise":          {{"America_Mountain", curatedFrom, 0}},
+	"America/Buenos_Aires":   {{"Argentina", curatedFrom, 0}},
+	"America/Cancun":         {{"America_Eastern", curatedFrom, 0}},
+	"America/Chicago":        {{"America_Central", curatedFrom, 0}},
+	"America/Ciudad_Juarez":  {{"America_Mountain", curatedFrom, 0}},
+	"America/Cordoba":        {{"Argentina", curatedFrom, 0}},
+	"America/Costa_Rica":     {{"America_Central", curatedFrom, 0}},
+	"America/Denver":         {{"America_Mountain", curatedFrom, 0}},
+	"America/Detroit":        {{"America_Eastern", curatedFrom, 0}},
+	"America/Edmonton":       {{"America_Mountain", curatedFrom, 0}},
+	"America/El_Salvador":    {{"America_Central", curatedFrom, 0}},
+	"America/Fortaleza":      {{"Brasilia", curatedFrom, 0}},
+	"America/Grand_Turk":     {{"America_Eastern", curatedFrom, 0}},
+	"America/Guatemala":      {{"America_Central", curatedFrom, 0}},
+	"America/Halifax":        {{"Atlantic", curatedFrom, 0}},
+	"America/Indianapolis":   {{"America_Eastern", curatedFrom, 0}},
+	"America/Iqaluit":        {{"America_Eastern", curatedFrom, 0}},
+	"America/Jamaica":        {{"America_Eastern", curatedFrom, 0}},
+	"America/Juneau":         {{"Alaska", curatedFrom, 0}},
+	"America/Lima":           {{"Peru", curatedFrom, 0}},
+	"America/Los_Angeles":    {{"America_Pacific", curatedFrom, 0}},
+	"America/Louisville":     {{"America_Eastern", curatedFrom, 0}},
+	"America/Managua":        {{"America_Central", curatedFrom, 0}},
+	"America/Martinique":     {{"Atlantic", curatedFrom, 0}},
+	"America/Matamoros":      {{"America_Central", curatedFrom, 0}},
+	"America/Merida":         {{"America_Central", curatedFrom, 0}},
+	"America/Mexico_City":    {{"America_Central", curatedFrom, 0}},
+	"America/Monterrey":      {{"America_Central", curatedFrom, 0}},
+	"America/Nassau":         {{"America_Eastern", curatedFrom, 0}},
+	"America/New_York":       {{"America_Eastern", curatedFrom, 0}},
+	"America/Panama":         {{"America_Eastern", curatedFrom, 0}},
+	"America/Phoenix":        {{"America_Mountain", curatedFrom, 0}},
+	"America/Port-au-Prince": {{"America_Eastern", curatedFrom, 0}},
+	"America/Puerto_Rico":    {{"Atlantic", curatedFrom, 0}},
+	"America/Recife":         {{"Brasilia", curatedFrom, 0}},
+	"America/Regina":         {{"America_Central", curatedFrom, 0}},
+	"America/Santiago":       {{"Chile", curatedFrom, 0}},
+	"America/Santo_Domingo":  {{"Atlantic", curatedFrom, 0}},
+	"America/Sao_Paulo":      {{"Brasilia", curatedFrom, 0}},
+	"America/St_Johns":       {{"Newfoundland", curatedFrom, 0}},
+	"America/Tegucigalpa":    {{"America_Central", curatedFrom, 0}},
+	"America/Tijuana":        {{"America_Pacific", curatedFrom, 0}},
+	"America/Toronto":        {{"America_Eastern", curatedFrom, 0}},
+	"America/Vancouver":      {{"America_Pacific", curatedFrom, 0}},
+	"America/Winnipeg":       {{"America_Central", curatedFrom, 0}},
+	"Asia/Aden":              {{"Arabian", curatedFrom, 0}},
+	"Asia/Baghdad":           {{"Arabian", curatedFrom, 0}},
+	"Asia/Bahrain":           {{"Arabian", curatedFrom, 0}},
+	"Asia/Bangkok":           {{"Indochina", curatedFrom, 0}},
+	"Asia/Beirut":            {{"Europe_Eastern", curatedFrom, 0}},
+	"Asia/Calcutta":          {{"India", curatedFrom, 0}},
+	"Asia/Colombo":           {{"India", curatedFrom, 0}},
+	"Asia/Dhaka":             {{"Bangladesh", curatedFrom, 0}},
+	"Asia/Dubai":             {{"Gulf", curatedFrom, 0}},
+	"Asia/Hong_Kong":         {{"Hong_Kong", curatedFrom, 0}},
+	"Asia/Jakarta":           {{"Indonesia_Western", curatedFrom, 0}},
+	"Asia/Jerusalem":         {{"Israel", curatedFrom, 0}},
+	"Asia/Karachi":           {{"Pakistan", curatedFrom, 0}},
+	"Asia/Kuwait":            {{"Arabian", curatedFrom, 0}},
+	"Asia/Macau":             {{"China", curatedFrom, 0}},
+	"Asia/Muscat":            {{"Gulf", curatedFrom, 0}},
+	"Asia/Nicosia":           {{"Europe_Eastern", curatedFrom, 0}},
+	"Asia/Phnom_Penh":        {{"Indochina", curatedFrom, 0}},
+	"Asia/Pontianak":         {{"Indonesia_Western", curatedFrom, 0}},
+	"Asia/Qatar":             {{"Arabian", curatedFrom, 0}},
+	"Asia/Riyadh":            {{"Arabian", curatedFrom, 0}},
+	"Asia/Saigon":            {{"Indochina", curatedFrom, 0}},
+	"Asia/Seoul":             {{"Korea", curatedFrom, 0}},
+	"Asia/Shanghai":          {{"China", curatedFrom, 0}},
+	"Asia/Singapore":         {{"Singapore", curatedFrom, 0}},
+	"Asia/Tehran":            {{"Iran", curatedFrom, 0}},
+	"Asia/Tokyo":             {{"Japan", curatedFrom, 0}},
+	"Asia/Vientiane":         {{"Indochina", curatedFrom, 0}},
+	"Atlantic/Bermuda":       {{"Atlantic", curatedFrom, 0}},
+	"Atlantic/Canary":        {{"Europe_Western", curatedFrom, 0}},
+	"Atlantic/Faeroe":        {{"Europe_Western", curatedFrom, 0}},
+	"Atlantic/Madeira":       {{"Europe_Western", curatedFrom, 0}},
+	"Atlantic/Reykjavik":     {{"GMT", curatedFrom, 0}},
+	"Australia/Adelaide":     {{"Australia_Central", curatedFrom, 0}},
+	"Australia/Brisbane":     {{"Australia_Eastern", curatedFrom, 0}},
+	"Australia/Darwin":       {{"Australia_Central", curatedFrom, 0}},
+	"Australia/Hobart":       {{"Australia_Eastern", curatedFrom, 0}},
+	"Australia/Melbourne":    {{"Australia_Eastern", curatedFrom, 0}},
+	"Australia/Perth":        {{"Australia_Western", curatedFrom, 0}},
+	"Australia/Sydney":       {{"Australia_Eastern", curatedFrom, 0}},
+	"Europe/Amsterdam":       {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Andorra":         {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Athens":          {{"Europe_Eastern", curatedFrom, 0}},
+	"Europe/Belgrade":        {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Berlin":          {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Bratislava":      {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Brussels":        {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Bucharest":       {{"Europe_Eastern", curatedFrom, 0}},
+	"Europe/Budapest":        {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Chisinau":        {{"Europe_Eastern", curatedFrom, 0}},
+	"Europe/Copenhagen":      {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Dublin":          {{"GMT", curatedFrom, 0}},
+	"Europe/Gibraltar":       {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Helsinki":        {{"Europe_Eastern", curatedFrom, 0}},
+	"Europe/Kaliningrad":     {{"Europe_Eastern", curatedFrom, 0}},
+	"Europe/Kiev":            {{"Europe_Eastern", curatedFrom, 0}},
+	"Europe/Lisbon":          {{"Europe_Western", curatedFrom, 0}},
+	"Europe/Ljubljana":       {{"Europe_Central", curatedFrom, 0}},
+	"Europe/London":          {{"GMT", curatedFrom, 0}},
+	"Europe/Luxembourg":      {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Madrid":          {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Malta":           {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Monaco":          {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Moscow":          {{"Moscow", curatedFrom, 0}},
+	"Europe/Oslo":            {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Paris":           {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Prague":          {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Riga":            {{"Europe_Eastern", curatedFrom, 0}},
+	"Europe/Rome":            {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Sarajevo":        {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Simferopol":      {{"Moscow", curatedFrom, 0}},
+	"Europe/Skopje":          {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Sofia":           {{"Europe_Eastern", curatedFrom, 0}},
+	"Europe/Stockholm":       {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Tallinn":         {{"Europe_Eastern", curatedFrom, 0}},
+	"Europe/Tirane":          {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Vaduz":           {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Vienna":          {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Vilnius":         {{"Europe_Eastern", curatedFrom, 0}},
+	"Europe/Warsaw":          {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Zagreb":          {{"Europe_Central", curatedFrom, 0}},
+	"Europe/Zurich":          {{"Europe_Central", curatedFrom, 0}},
+	"Pacific/Auckland":       {{"New_Zealand", curatedFrom, 0}},
+	"Pacific/Honolulu":       {{"Hawaii_Aleutian", curatedFrom, 0}},
+}
+
+// metazoneNames maps the locales and metazones to their names.
+var metazoneNames = map[string]map[string]Names{
+	"de": {
+		"Africa_Central":    {Long: Variants{Standard: "Zentralafrikanische Zeit"}},
+		"Africa_Eastern":    {Long: Variants{Standard: "Ostafrikanische Zeit"}},
+		"Africa_Southern":   {Long: Variants{Standard: "Südafrikanische Zeit"}},
+		"Africa_Western":    {Long: Variants{Generic: "Westafrikanische Zeit", Standard: "Westafrikanische Normalzeit", Daylight: "Westafrikanische Sommerzeit"}},
+		"Alaska":            {Long: Variants{Generic: "Alaska-Zeit", Standard: "Alaska-Normalzeit", Daylight: "Alaska-Sommerzeit"}},
+		"America_Central":   {Long: Variants{Generic: "Nordamerikanische Zentralzeit", Standard: "Nordamerikanische Zentral-Normalzeit", Daylight: "Nordamerikanische Zentral-Sommerzeit"}},
+		"America_Eastern":   {Long: Variants{Generic: "Nordamerikanische Ostküstenzeit", Standard: "Nordamerikanische Ostküsten-Normalzeit", Daylight: "Nordamerikanische Ostküsten-Sommerzeit"}},
+		"America_Mountain":  {Long: Variants{Generic: "Rocky-Mountain-Zeit", Standard: "Rocky-Mountain-Normalzeit", Daylight: "Rocky-Mountain-Sommerzeit"}},
+		"America_Pacific":   {Long: Variants{Generic: "Nordamerikanische Westküstenzeit", Standard: "Nordamerikanische Westküsten-Normalzeit", Daylight: "Nordamerikanische Westküsten-Sommerzeit"}},
+		"Arabian":           {Long: Variants{Generic: "Arabische Zeit", Standard: "Arabische Normalzeit", Daylight: "Arabische Sommerzeit"}},
+		"Argentina":         {Long: Variants{Generic: "Argentinische Zeit", Standard: "Argentinische Normalzeit", Daylight: "Argentinische Sommerzeit"}},
+		"Atlantic":          {Long: Variants{Generic: "Atlantik-Zeit", Standard: "Atlantik-Normalzeit", Daylight: "Atlantik-Sommerzeit"}},
+		"Australia_Central": {Long: Variants{Generic: "Zentralaustralische Zeit", Standard: "Zentralaustralische Normalzeit", Daylight: "Zentralaustralische Sommerzeit"}},
+		"Australia_Eastern": {Long: Variants{Generic: "Ostaustralische Zeit", Standard: "Ostaustralische Normalzeit", Daylight: "Ostaustralische Sommerzeit"}},
+		"Australia_Western": {Long: Variants{Generic: "Westaustralische Zeit", Standard: "Westaustralische Normalzeit", Daylight: "Westaustralische Sommerzeit"}},
+		"Bangladesh":        {Long: Variants{Generic: "Bangladesch-Zeit", Standard: "Bangladesch-Normalzeit", Daylight: "Bangladesch-Sommerzeit"}},
+		"Brasilia":          {Long: Variants{Generic: "Brasília-Zeit", Standard: "Brasília-Normalzeit", Daylight: "Brasília-Sommerzeit"}},
+		"Chile":             {Long: Variants{Generic: "Chilenische Zeit", Standard: "Chilenische Normalzeit", Daylight: "Chilenische Sommerzeit"}},
+		"China":             {Long: Variants{Generic: "Chinesische Zeit", Standard: "Chinesische Normalzeit", Daylight: "Chinesische Sommerzeit"}},
+		"Colombia":          {Long: Variants{Generic: "Kolumbianische Zeit", Standard: "Kolumbianische Normalzeit", Daylight: "Kolumbianische Sommerzeit"}},
+		"Europe_Central":    {Long: Variants{Generic: "Mitteleuropäische Zeit", Standard: "Mitteleuropäische Normalzeit", Daylight: "Mitteleuropäische Sommerzeit"}, Short: Variants{Generic: "MEZ", Standard: "MEZ", Daylight: "MESZ"}},
+		"Europe_Eastern":    {Long: Variants{Generic: "Osteuropäische Zeit", Standard: "Osteuropäische Normalzeit", Daylight: "Osteuropäische Sommerzeit"}, Short: Variants{Generic: "OEZ", Standard: "OEZ", Daylight: "OESZ"}},
+		"Europe_Western":    {Long: Variants{Generic: "Westeuropäische Zeit", Standard: "Westeuropäische Normalzeit", Daylight: "Westeuropäische Sommerzeit"}, Short: Variants{Generic: "WEZ", Standard: "WEZ", Daylight: "WESZ"}},
+		"GMT":               {Long: Variants{Standard: "Mittlere Greenwich-Zeit"}},
+		"Gulf":              {Long: Variants{Standard: "Golf-Zeit"}},
+		"Hawaii_Aleutian":   {Long: Variants{Generic: "Hawaii-Aleuten-Zeit", Standard: "Hawaii-Aleuten-Normalzeit", Daylight: "Hawaii-Aleuten-Sommerzeit"}},
+		"Hong_Kong":         {Long: Variants{Generic: "Hongkong-Zeit", Standard: "Hongkong-Normalzeit", Daylight: "Hongkong-Sommerzeit"}},
+		"India":             {Long: Variants{Standard: "Indische Normalzeit"}},
+		"Indochina":         {Long: Variants{Standard: "Indochina-Zeit"}},
+		"Indonesia_Western": {Long: Variants{Standard: "Westindonesische Zeit"}},
+		"Iran":              {Long: Variants{Generic: "Iranische Zeit", Standard: "Iranische Normalzeit", Daylight: "Iranische Sommerzeit"}},
+		"Israel":            {Long: Variants{Generic: "Israelische Zeit", Standard: "Israelische Normalzeit", Daylight: "Israelische Sommerzeit"}},
+		"Japan":             {Long: Variants{Generic: "Japanische Zeit", Standard: "Japanische Normalzeit", Daylight: "Japanische Sommerzeit"}},
+		"Korea":             {Long: Variants{Generic: "Koreanische Zeit", Standard: "Koreanische Normalzeit", Daylight: "Koreanische Sommerzeit"}},
+		"Moscow":            {Long: Variants{Generic: "Moskauer Zeit", Standard: "Moskauer Normalzeit", Daylight: "Moskauer Sommerzeit"}},
+		"New_Zealand":       {Long: Variants{Generic: "Neuseeland-Zeit", Standard: "Neuseeland-Normalzeit", Daylight: "Neuseeland-Sommerzeit"}},
+		"Newfoundland":      {Long: Variants{Generic: "Neufundland-Zeit", Standard: "Neufundland-Normalzeit", Daylight: "Neufundland-Sommerzeit"}},
+		"Pakistan":          {Long: Variants{Generic: "Pakistanische Zeit", Standard: "Pakistanische Normalzeit", Daylight: "Pakistanische Sommerzeit"}},
+		"Peru":              {Long: Variants{Generic: "Peruanische Zeit", Standard: "Peruanische Normalzeit", Daylight: "Peruanische Sommerzeit"}},
+		"Singapore":         {Long: Variants{Standard: "Singapur-Zeit"}},
+	},
+	"en": {
+		"Africa_Central":    {Long: Variants{Standard: "Central Africa Time"}},
+		"Africa_Eastern":    {Long: Variants{Standard: "East Africa Time"}},
+		"Africa_Southern":   {Long: Variants{Standard: "South Africa Standard Time"}},
+		"Africa_Western":    {Long: Variants{Generic: "West Africa Time", Standard: "West Africa Standard Time", Daylight: "West Africa Summer Time"}},
+		"Alaska":            {Long: Variants{Generic: "Alaska Time", Standard: "Alaska Standard Time", Daylight: "Alaska Daylight Time"}, Short: Variants{Generic: "AKT", Standard: "AKST", Daylight: "AKDT"}},
+		"America_Central":   {Long: Variants{Generic: "Central Time", Standard: "Central Standard Time", Daylight: "Central Daylight Time"}, Short: Variants{Generic: "CT", Standard: "CST", Daylight: "CDT"}},
+		"America_Eastern":   {Long: Variants{Generic: "Eastern Time", Standard: "Eastern Standard Time", Daylight: "Eastern Daylight Time"}, Short: Variants{Generic: "ET", Standard: "EST", Daylight: "EDT"}},
+		"America_Mountain":  {Long: Variants{Generic: "Mountain Time", Standard: "Mountain Standard Time", Daylight: "Mountain Daylight Time"}, Short: Variants{Generic: "MT", Standard: "MST", Daylight: "MDT"}},
+		"America_Pacific":   {Long: Variants{Generic: "Pacific Time", Standard: "Pacific Standard Time", Daylight: "Pacific Daylight Time"}, Short: Variants{Generic: "PT", Standard: "PST", Daylight: "PDT"}},
+		"Arabian":           {Long: Variants{Generic: "Arabian Time", Standard: "Arabian Standard Time", Daylight: "Arabian Daylight Time"}},
+		"Argentina":         {Long: Variants{Generic: "Argentina Time", Standard: "Argentina Standard Time", Daylight: "Argentina Summer Time"}},
+		"Atlantic":          {Long: Variants{Generic: "Atlantic Time", Standard: "Atlantic Standard Time", Daylight: "Atlantic Daylight Time"}, Short: Variants{Generic: "AT", Standard: "AST", Daylight: "ADT"}},
+		"Australia_Central": {Long: Variants{Generic: "Central Australia Time", Standard: "Australian Central Standard Time", Daylight: "Australian Central Daylight Time"}},
+		"Australia_Eastern": {Long: Variants{Generic: "Eastern Australia Time", Standard: "Australian Eastern Standard Time", Daylight: "Australian Eastern Daylight Time"}},
+		"Australia_Western": {Long: Variants{Generic: "Western Australia Time", Standard: "Australian Western Standard Time", Daylight: "Australian Western Daylight Time"}},
+		"Bangladesh":        {Long: Variants{Generic: "Bangladesh Time", Standard: "Bangladesh Standard Time", Daylight: "Bangladesh Summer Time"}},
+		"Brasilia":          {Long: Variants{Generic: "Brasilia Time", Standard: "Brasilia Standard Time", Daylight: "Brasilia Summer Time"}},
+		"Chile":             {Long: Variants{Generic: "Chile Time", Standard: "Chile Standard Time", Daylight: "Chile Summer Time"}},
+		"China":             {Long: Variants{Generic: "China Time", Standard: "China Standard Time", Daylight: "China Daylight Time"}},
+		"Colombia":          {Long: Variants{Generic: "Colombia Time", Standard: "Colombia Standard Time", Daylight: "Colombia Summer Time"}},
+		"Europe_Central":    {Long: Variants{Generic: "Central European Time", Standard: "Central European Standard Time", Daylight: "Central European Summer Time"}},
+		"Europe_Eastern":    {Long: Variants{Generic: "Eastern European Time", Standard: "Eastern European Standard Time", Daylight: "Eastern European Summer Time"}},
+		"Europe_Western":    {Long: Variants{Generic: "Western European Time", Standard: "Western European Standard Time", Daylight: "Western European Summer Time"}},
+		"GMT":               {Long: Variants{Standard: "Greenwich Mean Time"}, Short: Variants{Standard: "GMT"}},
+		"Gulf":              {Long: Variants{Standard: "Gulf Standard Time"}},
+		"Hawaii_Aleutian":   {Long: Variants{Generic: "Hawaii-Aleutian Time", Standard: "Hawaii-Aleutian Standard Time", Daylight: "Hawaii-Aleutian Daylight Time"}, Short: Variants{Generic: "HAST", Standard: "HAST", Daylight: "HADT"}},
+		"Hong_Kong":         {Long: Variants{Generic: "Hong Kong Time", Standard: "Hong Kong Standard Time", Daylight: "Hong Kong Summer Time"}},
+		"India":             {Long: Variants{Standard: "India Standard Time"}},
+		"Indochina":         {Long: Variants{Standard: "Indochina Time"}},
+		"Indonesia_Western": {Long: Variants{Standard: "Western Indonesia Time"}},
+		"Iran":              {Long: Variants{Generic: "Iran Time", Standard: "Iran Standard Time", Daylight: "Iran Daylight Time"}},
+		"Israel":            {Long: Variants{Generic: "Israel Time", Standard: "Israel Standard Time", Daylight: "Israel Daylight Time"}},
+		"Japan":             {Long: Variants{Generic: "Japan Time", Standard: "Japan Standard Time", Daylight: "Japan Daylight Time"}},
+		"Korea":             {Long: Variants{Generic: "Korean Time", Standard: "Korean Standard Time", Daylight: "Korean Daylight Time"}},
+		"Moscow":            {Long: Variants{Generic: "Moscow Time", Standard: "Moscow Standard Time", Daylight: "Moscow Summer Time"}},
+		"New_Zealand":       {Long: Variants{Generic: "New Zealand Time", Standard: "New Zealand Standard Time", Daylight: "New Zealand Daylight Time"}},
+		"Newfoundland":      {Long: Variants{Generic: "Newfoundland Time", Standard: "Newfoundland Standard Time", Daylight: "Newfoundland Daylight Time"}},
+		"Pakistan":          {Long: Variants{Generic: "Pakistan Time", Standard: "Pakistan Standard Time", Daylight: "Pakistan Summer Time"}},
+		"Peru":              {Long: Variants{Generic: "Peru Time", Standard: "Peru Standard Time", Daylight: "Peru Summer Time"}},
+		"Singapore":         {Long: Variants{Standard: "Singapore Standard Time"}},
+	},
+}
+
+// zoneNames maps the locales and CLDR zone identifiers to their zone specific names.
+var zoneNames = map[string]map[string]Names{
+	"de": {
+		"Etc/UTC":       {Long: Variants{Standard: "Koordinierte Weltzeit"}},
+		"Europe/Dublin": {Long: Variants{Daylight: "Irische Sommerzeit"}},
+		"Europe/London": {Long: Variants{Daylight: "Britische Sommerzeit"}},
+	},
+	"en": {
+		"Etc/UTC":          {Long: Variants{Standard: "Coordinated Universal Time"}, Short: Variants{Standard: "UTC"}},
+		"Europe/Dublin":    {Long: Variants{Daylight: "Irish Standard Time"}},
+		"Europe/London":    {Long: Variants{Daylight: "British Summer Time"}},
+		"Pacific/Honolulu": {Short: Variants{Generic: "HST", Standard: "HST", Daylight: "HDT"}},
+	},
+}
@@ -0,0 +1,333 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tznames provides localized time zone display names,
+// i.e. "Central European Summer Time" or "Mitteleuropäische Sommerzeit".
+// The names come from the CLDR metaZones and timeZoneNames data. The checked-in tables are
+// a hand-curated subset of the common metazones in the en and de locales, valid since 2023 only;
+// go generate replaces them with the full tables, including the history of the metazones,
+// for the locales configured with the -locales flag of the generator.
+package tznames
+
+//go:generate go run ./cmd/update_tznames.go -locales en,de
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blockysource/go-pkg/times/internal/tzlocal"
+	"github.com/blockysource/go-pkg/times/internal/tzzones"
+)
+
+// Width is the width of the zone name.
+type Width int
+
+const (
+	// Long is the long name, i.e. "Pacific Standard Time".
+	Long Width = iota
+	// Short is the abbreviated name, i.e. "PST".
+	Short
+)
+
+// Kind is the kind of the zone name variant.
+type Kind int
+
+const (
+	// Generic is the name used regardless of daylight saving time.
+	Generic Kind = iota
+	// Standard is the name used in standard time.
+	Standard
+	// Daylight is the name used in daylight saving time.
+	Daylight
+)
+
+// Variants contains the generic, standard and daylight variants of a zone name.
+type Variants struct {
+	// Generic is the name used regardless of daylight saving time, i.e. "Pacific Time".
+	Generic string
+	// Standard is the name used in standard time, i.e. "Pacific Standard Time".
+	Standard string
+	// Daylight is the name used in daylight saving time, i.e. "Pacific Daylight Time".
+	Daylight string
+}
+
+// Names contains the long and short names of a zone.
+type Names struct {
+	Long  Variants
+	Short Variants
+}
+
+// Variants returns the variants of given width.
+func (n Names) Variants(width Width) Variants {
+	if width == Short {
+		return n.Short
+	}
+	return n.Long
+}
+
+// Locales returns the locales with generated zone names.
+func Locales() []string {
+	locales := make([]string, 0, len(metazoneNames))
+	for locale := range metazoneNames {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// metazonePeriod is the metazone used by a zone in the period [from, to), in the unix seconds.
+// The zero from or to means the period is unbounded.
+type metazonePeriod struct {
+	mz       string
+	from, to int64
+}
+
+func (p metazonePeriod) contains(sec int64) bool {
+	return (p.from == 0 || sec >= p.from) && (p.to == 0 || sec < p.to)
+}
+
+// metazone returns the metazone of the CLDR zone identifier at the instant t, or the current one for the zero t.
+func metazone(id string, t time.Time) (string, bool) {
+	for _, p := range metazones[id] {
+		if t.IsZero() && p.to == 0 || !t.IsZero() && p.contains(t.Unix()) {
+			return p.mz, true
+		}
+	}
+	return "", false
+}
+
+// Lookup returns the current names of the IANA zone in given locale, i.e. "de" or "en-US".
+// If the region specific locale is not generated, its language is used instead.
+// The zone specific names take precedence over the names of the zone's metazone,
+// i.e. "British Summer Time" for Europe/London.
+func Lookup(locale, zone string) (Names, bool) {
+	return LookupAt(locale, zone, time.Time{})
+}
+
+// LookupAt is the variant of Lookup, which returns the names of the zone's metazone at the instant t,
+// i.e. "Moscow Standard Time" or "Eastern European Standard Time" for Europe/Moscow, depending on the year.
+func LookupAt(locale, zone string, t time.Time) (Names, bool) {
+	locale, ok := matchLocale(locale)
+	if !ok {
+		return Names{}, false
+	}
+
+	var names Names
+	var found bool
+	for _, id := range cldrZoneIDs(zone) {
+		mz, ok := metazone(id, t)
+		if !ok {
+			continue
+		}
+		names, found = metazoneNames[locale][mz]
+		break
+	}
+	for _, id := range cldrZoneIDs(zone) {
+		zn, ok := zoneNames[locale][id]
+		if !ok {
+			continue
+		}
+		names.Long = mergeVariants(names.Long, zn.Long)
+		names.Short = mergeVariants(names.Short, zn.Short)
+		found = true
+		break
+	}
+	return names, found
+}
+
+// Find returns the IANA zone currently having given name in the locale, and the kind of the name,
+// i.e. "Mitteleuropäische Sommerzeit" in "de" -> Europe/Berlin, Daylight.
+// The name is compared case-insensitive, with both long and short widths.
+// If multiple zones share the metazone name, the zone representing a Windows time zone
+// is preferred, i.e. Europe/Berlin rather than Europe/Amsterdam.
+func Find(locale, name string) (zone string, kind Kind, ok bool) {
+	locale, ok = matchLocale(locale)
+	if !ok {
+		return "", 0, false
+	}
+	for _, id := range sortedNames(zoneNames[locale]) {
+		if kind, ok = matchNames(zoneNames[locale][id], name); ok {
+			return id, kind, true
+		}
+	}
+	for _, mz := range sortedNames(metazoneNames[locale]) {
+		if kind, ok = matchNames(metazoneNames[locale][mz], name); !ok {
+			continue
+		}
+		var candidates []string
+		for id := range metazones {
+			if zmz, _ := metazone(id, time.Time{}); zmz == mz {
+				candidates = append(candidates, id)
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			pi, pj := isPrimaryZone(candidates[i]), isPrimaryZone(candidates[j])
+			if pi != pj {
+				return pi
+			}
+			return candidates[i] < candidates[j]
+		})
+		return candidates[0], kind, true
+	}
+	return "", 0, false
+}
+
+func matchNames(names Names, name string) (Kind, bool) {
+	for _, v := range []Variants{names.Long, names.Short} {
+		switch {
+		case v.Standard != "" && strings.EqualFold(v.Standard, name):
+			return Standard, true
+		case v.Daylight != "" && strings.EqualFold(v.Daylight, name):
+			return Daylight, true
+		case v.Generic != "" && strings.EqualFold(v.Generic, name):
+			return Generic, true
+		}
+	}
+	return 0, false
+}
+
+func sortedNames(m map[string]Names) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isPrimaryZone checks if the zone, or any of its aliases, represents a Windows time zone.
+func isPrimaryZone(zone string) bool {
+	for _, id := range cldrZoneIDs(zone) {
+		for _, name := range tzlocal.WinTZtoIANA {
+			if name == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Name returns the localized name of the location at the instant t,
+// i.e. "Central European Summer Time" for Europe/Berlin in July.
+// The names are of the metazone the location used at t, see LookupAt.
+// The daylight name is used if t is in daylight saving time, otherwise the standard name.
+// If the locale has no such name, the localized GMT format is returned, i.e. "GMT+02:00".
+func Name(locale string, loc *time.Location, t time.Time, width Width) string {
+	t = t.In(loc)
+	names, _ := LookupAt(locale, loc.String(), t)
+	v := names.Variants(width)
+
+	name := v.Standard
+	if t.IsDST() {
+		name = v.Daylight
+	}
+	if name == "" && !t.IsDST() {
+		name = v.Generic
+	}
+	if name == "" {
+		name = gmtFormat(t, width)
+	}
+	return name
+}
+
+// GenericName returns the localized generic name of the location at the instant t, i.e. "Pacific Time".
+// If the locale has no generic name, the name of the instant t is returned (see Name).
+func GenericName(locale string, loc *time.Location, t time.Time, width Width) string {
+	names, _ := LookupAt(locale, loc.String(), t)
+	if name := names.Variants(width).Generic; name != "" {
+		return name
+	}
+	return Name(locale, loc, t, width)
+}
+
+// gmtFormat formats the offset of t, i.e. "GMT+02:00" or "GMT+2" for the short width.
+func gmtFormat(t time.Time, width Width) string {
+	_, offset := t.Zone()
+	if offset == 0 {
+		return "GMT"
+	}
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	hours, minutes := offset/3600, offset%3600/60
+	if width == Short {
+		if minutes == 0 {
+			return fmt.Sprintf("GMT%c%d", sign, hours)
+		}
+		return fmt.Sprintf("GMT%c%d:%02d", sign, hours, minutes)
+	}
+	return fmt.Sprintf("GMT%c%02d:%02d", sign, hours, minutes)
+}
+
+func mergeVariants(v, override Variants) Variants {
+	if override.Generic != "" {
+		v.Generic = override.Generic
+	}
+	if override.Standard != "" {
+		v.Standard = override.Standard
+	}
+	if override.Daylight != "" {
+		v.Daylight = override.Daylight
+	}
+	return v
+}
+
+// matchLocale returns the generated locale matching given locale or its language.
+func matchLocale(locale string) (string, bool) {
+	locale = strings.ReplaceAll(locale, "_", "-")
+	for {
+		for generated := range metazoneNames {
+			if strings.EqualFold(generated, locale) {
+				return generated, true
+			}
+		}
+		i := strings.LastIndexByte(locale, '-')
+		if i < 0 {
+			return "", false
+		}
+		locale = locale[:i]
+	}
+}
+
+var aliases struct {
+	once  sync.Once
+	names map[string][]string
+}
+
+// cldrZoneIDs returns the zone and all its aliases.
+// The CLDR keeps the zone identifiers stable, thus it may use an alias of the IANA name,
+// i.e. Asia/Calcutta for Asia/Kolkata.
+func cldrZoneIDs(zone string) []string {
+	aliases.once.Do(func() {
+		aliases.names = make(map[string][]string)
+		for alias, target := range tzzones.Links {
+			aliases.names[target] = append(aliases.names[target], alias)
+		}
+		for _, names := range aliases.names {
+			sort.Strings(names)
+		}
+	})
+	if target, ok := tzzones.Links[zone]; ok {
+		zone = target
+	}
+	return append([]string{zone}, aliases.names[zone]...)
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tznames
+
+import (
+	"testing"
+	"time"
+)
+
+func TestName(t *testing.T) {
+	winter := time.Date(2023, time.January, 15, 12, 0, 0, 0, time.UTC)
+	summer := time.Date(2023, time.July, 15, 12, 0, 0, 0, time.UTC)
+	for _, test := range []struct {
+		locale string
+		zone   string
+		t      time.Time
+		width  Width
+		want   string
+	}{
+		{locale: "en", zone: "Europe/Berlin", t: summer, want: "Central European Summer Time"},
+		{locale: "de-DE", zone: "Europe/Berlin", t: summer, want: "Mitteleuropäische Sommerzeit"},
+		{locale: "de", zone: "Europe/Berlin", t: winter, width: Short, want: "MEZ"},
+		{locale: "en_US", zone: "America/Los_Angeles", t: winter, width: Short, want: "PST"},
+		{locale: "en", zone: "US/Pacific", t: summer, want: "Pacific Daylight Time"},
+		{locale: "en", zone: "Europe/London", t: summer, want: "British Summer Time"},
+		{locale: "en", zone: "Europe/London", t: winter, want: "Greenwich Mean Time"},
+		{locale: "en", zone: "Asia/Kolkata", t: summer, want: "India Standard Time"},
+		{locale: "en", zone: "UTC", t: summer, want: "Coordinated Universal Time"},
+		{locale: "de", zone: "America/Los_Angeles", t: summer, width: Short, want: "GMT-7"},
+		{locale: "xx", zone: "Asia/Kolkata", t: summer, want: "GMT+05:30"},
+	} {
+		t.Run(test.locale+"/"+test.zone, func(t *testing.T) {
+			loc, err := time.LoadLocation(test.zone)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := Name(test.locale, loc, test.t, test.width); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGenericName(t *testing.T) {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := GenericName("en", loc, time.Now(), Long); got != "Pacific Time" {
+		t.Errorf("got %q, want %q", got, "Pacific Time")
+	}
+	if got := GenericName("en", loc, time.Now(), Short); got != "PT" {
+		t.Errorf("got %q, want %q", got, "PT")
+	}
+}
+
+func TestNameAtHistoricalInstant(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(periods []metazonePeriod) { metazones["Europe/Moscow"] = periods }(metazones["Europe/Moscow"])
+	// A made up history of Europe/Moscow, to test the metazone periods.
+	metazones["Europe/Moscow"] = []metazonePeriod{
+		{"Moscow", 0, 1301180400},
+		{"Europe_Eastern", 1301180400, 1414274400},
+		{"Moscow", 1414274400, 0},
+	}
+	winter2012 := time.Date(2012, time.January, 15, 12, 0, 0, 0, time.UTC)
+	if got, want := Name("en", loc, winter2012, Long), "Eastern European Standard Time"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := Name("en", loc, time.Date(2023, time.July, 15, 12, 0, 0, 0, time.UTC), Long), "Moscow Standard Time"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if names, _ := Lookup("en", "Europe/Moscow"); names.Long.Standard != "Moscow Standard Time" {
+		t.Errorf("got current names %v, want Moscow Standard Time", names.Long)
+	}
+
+	// The curated tables have no history, the earlier instants use the GMT format.
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := Name("en", berlin, time.Date(2000, time.July, 15, 12, 0, 0, 0, time.UTC), Long), "GMT+02:00"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
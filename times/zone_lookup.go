@@ -0,0 +1,384 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blockysource/go-pkg/times/internal/tzlocal"
+	"github.com/blockysource/go-pkg/times/internal/tzzones"
+)
+
+// ZoneMatch describes how a zone candidate matched the lookup input.
+type ZoneMatch int
+
+const (
+	// ZoneMatchExact is a match of the exact IANA zone name.
+	ZoneMatchExact ZoneMatch = iota
+	// ZoneMatchName is a case-insensitive match of the IANA zone name.
+	ZoneMatchName
+	// ZoneMatchCity is a match of the city (last) part of the IANA zone name.
+	ZoneMatchCity
+	// ZoneMatchAbbreviation is a match of the abbreviation currently used by the zone.
+	ZoneMatchAbbreviation
+)
+
+// String implements fmt.Stringer.
+func (m ZoneMatch) String() string {
+	switch m {
+	case ZoneMatchExact:
+		return "exact"
+	case ZoneMatchName:
+		return "name"
+	case ZoneMatchCity:
+		return "city"
+	case ZoneMatchAbbreviation:
+		return "abbreviation"
+	}
+	return fmt.Sprintf("ZoneMatch(%d)", int(m))
+}
+
+// ZoneCandidate is a time zone matching the lookup input.
+type ZoneCandidate struct {
+	// Name is the IANA name of the zone.
+	Name string
+	// Location is the loaded zone location.
+	Location *time.Location
+	// Match describes how the zone matched the input.
+	Match ZoneMatch
+	// Countries are the ISO 3166 codes of the countries using the zone.
+	Countries []string
+}
+
+// UnknownZoneError is returned when no time zone matches the lookup input.
+type UnknownZoneError struct {
+	Input       string   // The lookup input.
+	Suggestions []string // The zone names similar to the input, if any.
+}
+
+func (e *UnknownZoneError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("unknown time zone %q", e.Input)
+	}
+	quoted := make([]string, len(e.Suggestions))
+	for i, name := range e.Suggestions {
+		quoted[i] = strconv.Quote(name)
+	}
+	return fmt.Sprintf("unknown time zone %q; did you mean %s?", e.Input, strings.Join(quoted, ", "))
+}
+
+// LookupZone resolves user provided time zone input to the matching zones.
+// In comparison to LoadLocation, the input is matched:
+//
+//   - case-insensitive, i.e. "europe/berlin" -> Europe/Berlin
+//   - by the city name, i.e. "Berlin" or "new york" -> Europe/Berlin, America/New_York
+//   - by the abbreviation currently in use, i.e. "PST" -> America/Los_Angeles, America/Tijuana, ...
+//
+// Candidates are ranked by the kind of match, in the order listed above after the exact match.
+// Within the same kind of match, the zones representing a Windows time zone are preferred.
+// If nothing matches, an *UnknownZoneError with "did you mean" suggestions is returned.
+func LookupZone(input string) ([]ZoneCandidate, error) {
+	return LookupZoneInCountry(input, "")
+}
+
+// LookupZoneInCountry works like LookupZone, but it ranks the zones
+// used by the country with given ISO 3166 code first, i.e. "CST" in "CN" -> Asia/Shanghai.
+func LookupZoneInCountry(input, country string) ([]ZoneCandidate, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, errors.New("empty time zone name")
+	}
+	idx := zoneIndexes()
+	country = strings.ToUpper(country)
+
+	var candidates []ZoneCandidate
+	seen := make(map[string]bool)
+	add := func(match ZoneMatch, names []string) {
+		names = idx.rank(names, country)
+		for _, name := range names {
+			if seen[name] {
+				continue
+			}
+			loc, err := time.LoadLocation(name)
+			if err != nil {
+				continue
+			}
+			seen[name] = true
+			candidates = append(candidates, ZoneCandidate{
+				Name:      name,
+				Location:  loc,
+				Match:     match,
+				Countries: idx.countriesOf(name),
+			})
+		}
+	}
+
+	if input != "Local" {
+		if _, err := time.LoadLocation(input); err == nil {
+			add(ZoneMatchExact, []string{input})
+		}
+	}
+	if name, ok := idx.byName[normalizeZoneName(input)]; ok {
+		add(ZoneMatchName, []string{name})
+	}
+	add(ZoneMatchCity, idx.byCity[normalizeCityName(input)])
+	if isZoneAbbreviation(input) {
+		add(ZoneMatchAbbreviation, zoneAbbreviations()[strings.ToUpper(input)])
+	}
+
+	if len(candidates) == 0 {
+		return nil, &UnknownZoneError{Input: input, Suggestions: idx.suggest(input)}
+	}
+	return candidates, nil
+}
+
+// zoneIndex contains the lookup indexes of zone names.
+type zoneIndex struct {
+	names     []string            // All zone and link names.
+	byName    map[string]string   // Normalized name -> name.
+	byCity    map[string][]string // Normalized city -> names.
+	countries map[string][]string // Name -> country codes.
+	primary   map[string]bool     // Zones representing a Windows time zone.
+}
+
+var zoneIdx struct {
+	once sync.Once
+	idx  *zoneIndex
+}
+
+func zoneIndexes() *zoneIndex {
+	zoneIdx.once.Do(func() {
+		idx := &zoneIndex{
+			byName:    make(map[string]string),
+			byCity:    make(map[string][]string),
+			countries: make(map[string][]string),
+			primary:   make(map[string]bool),
+		}
+		addName := func(name string) {
+			if _, ok := idx.byName[normalizeZoneName(name)]; ok {
+				return
+			}
+			idx.names = append(idx.names, name)
+			idx.byName[normalizeZoneName(name)] = name
+			if i := strings.LastIndexByte(name, '/'); i >= 0 && !strings.HasPrefix(name, "Etc/") {
+				city := normalizeCityName(name[i+1:])
+				idx.byCity[city] = append(idx.byCity[city], name)
+			}
+		}
+		for _, z := range tzzones.Zones {
+			addName(z.Name)
+			idx.countries[z.Name] = z.Countries
+		}
+		for link := range tzzones.Links {
+			addName(link)
+		}
+		sort.Strings(idx.names)
+
+		for _, name := range tzlocal.WinTZtoIANA {
+			idx.primary[name] = true
+			if target, ok := tzzones.Links[name]; ok {
+				idx.primary[target] = true
+			}
+		}
+		zoneIdx.idx = idx
+	})
+	return zoneIdx.idx
+}
+
+func (idx *zoneIndex) countriesOf(name string) []string {
+	if cc, ok := idx.countries[name]; ok {
+		return cc
+	}
+	return idx.countries[tzzones.Links[name]]
+}
+
+// rank sorts the zone names by the country hint and whether the zone is primary.
+// A link is dropped if its target is among the names as well.
+func (idx *zoneIndex) rank(names []string, country string) []string {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	ranked := make([]string, 0, len(names))
+	for _, name := range names {
+		if target, ok := tzzones.Links[name]; ok && set[target] {
+			continue
+		}
+		ranked = append(ranked, name)
+	}
+
+	score := func(name string) int {
+		var s int
+		if country != "" {
+			for _, cc := range idx.countriesOf(name) {
+				if cc == country {
+					s += 2
+					break
+				}
+			}
+		}
+		if idx.primary[name] {
+			s++
+		}
+		return s
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		si, sj := score(ranked[i]), score(ranked[j])
+		if si != sj {
+			return si > sj
+		}
+		return ranked[i] < ranked[j]
+	})
+	return ranked
+}
+
+// maxSuggestions is the maximum number of "did you mean" suggestions.
+const maxSuggestions = 5
+
+// suggest returns the zone names whose name or city is within a small edit distance of the input.
+func (idx *zoneIndex) suggest(input string) []string {
+	in := normalizeZoneName(input)
+	maxDist := len(in) / 3
+	if maxDist < 1 {
+		maxDist = 1
+	}
+
+	type suggestion struct {
+		name string
+		dist int
+	}
+	var found []suggestion
+	for _, name := range idx.names {
+		d := levenshtein(in, normalizeZoneName(name))
+		if i := strings.LastIndexByte(name, '/'); i >= 0 {
+			if cd := levenshtein(normalizeCityName(input), normalizeCityName(name[i+1:])); cd < d {
+				d = cd
+			}
+		}
+		if d <= maxDist {
+			found = append(found, suggestion{name: name, dist: d})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].dist != found[j].dist {
+			return found[i].dist < found[j].dist
+		}
+		return found[i].name < found[j].name
+	})
+
+	var out []string
+	for _, s := range found {
+		if len(out) == maxSuggestions {
+			break
+		}
+		out = append(out, s.name)
+	}
+	return out
+}
+
+var zoneAbbrevs struct {
+	once    sync.Once
+	abbrevs map[string][]string
+}
+
+// zoneAbbreviations returns the zones by the abbreviations they use in the current year.
+// Loading all the zones is expensive, thus it is done only on the first abbreviation lookup.
+func zoneAbbreviations() map[string][]string {
+	zoneAbbrevs.once.Do(func() {
+		year := time.Now().Year()
+		instants := []time.Time{
+			time.Date(year, time.January, 1, 12, 0, 0, 0, time.UTC),
+			time.Date(year, time.July, 1, 12, 0, 0, 0, time.UTC),
+		}
+		abbrevs := make(map[string][]string)
+		for _, z := range tzzones.Zones {
+			loc, err := time.LoadLocation(z.Name)
+			if err != nil {
+				continue
+			}
+			var prev string
+			for _, t := range instants {
+				abbr, _ := t.In(loc).Zone()
+				if abbr == prev || !isZoneAbbreviation(abbr) {
+					continue
+				}
+				prev = abbr
+				abbrevs[abbr] = append(abbrevs[abbr], z.Name)
+			}
+		}
+		zoneAbbrevs.abbrevs = abbrevs
+	})
+	return zoneAbbrevs.abbrevs
+}
+
+// isZoneAbbreviation checks if s looks like a zone abbreviation, i.e. "CET" or "AEDT".
+// Numeric abbreviations like "+03" are not considered.
+func isZoneAbbreviation(s string) bool {
+	if len(s) < 2 || len(s) > 5 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeZoneName(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), "_"))
+}
+
+func normalizeCityName(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(strings.ReplaceAll(s, "_", " ")), " "))
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func minInt(v int, vs ...int) int {
+	for _, x := range vs {
+		if x < v {
+			v = x
+		}
+	}
+	return v
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLookupZone(t *testing.T) {
+	for _, test := range []struct {
+		input     string
+		country   string
+		wantFirst string
+		wantMatch ZoneMatch
+	}{
+		{input: "Europe/Berlin", wantFirst: "Europe/Berlin", wantMatch: ZoneMatchExact},
+		{input: "europe/berlin", wantFirst: "Europe/Berlin", wantMatch: ZoneMatchName},
+		{input: " us/pacific ", wantFirst: "US/Pacific", wantMatch: ZoneMatchName},
+		{input: "Berlin", wantFirst: "Europe/Berlin", wantMatch: ZoneMatchCity},
+		{input: "new york", wantFirst: "America/New_York", wantMatch: ZoneMatchCity},
+		{input: "PST", wantFirst: "America/Los_Angeles", wantMatch: ZoneMatchAbbreviation},
+		{input: "cst", country: "CN", wantFirst: "Asia/Shanghai", wantMatch: ZoneMatchAbbreviation},
+		{input: "CST", country: "US", wantFirst: "America/Chicago", wantMatch: ZoneMatchAbbreviation},
+	} {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := LookupZoneInCountry(test.input, test.country)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got[0].Name != test.wantFirst {
+				t.Errorf("first candidate: got %s, want %s", got[0].Name, test.wantFirst)
+			}
+			if got[0].Match != test.wantMatch {
+				t.Errorf("match: got %s, want %s", got[0].Match, test.wantMatch)
+			}
+			if got[0].Location == nil {
+				t.Errorf("location is nil")
+			}
+		})
+	}
+}
+
+func TestLookupZoneAmbiguous(t *testing.T) {
+	got, err := LookupZone("CET")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) < 2 {
+		t.Fatalf("got %d candidates, want more than one", len(got))
+	}
+	if got[0].Match != ZoneMatchExact {
+		t.Errorf("match: got %s, want %s", got[0].Match, ZoneMatchExact)
+	}
+}
+
+func TestLookupZoneSuggestions(t *testing.T) {
+	_, err := LookupZone("Europe/Berln")
+	var uerr *UnknownZoneError
+	if !errors.As(err, &uerr) {
+		t.Fatalf("got error %v, want *UnknownZoneError", err)
+	}
+	if len(uerr.Suggestions) == 0 || uerr.Suggestions[0] != "Europe/Berlin" {
+		t.Errorf("suggestions: got %v, want Europe/Berlin first", uerr.Suggestions)
+	}
+	got := (&UnknownZoneError{Input: "x", Suggestions: []string{"A/B", "C"}}).Error()
+	if want := `unknown time zone "x"; did you mean "A/B", "C"?`; got != want {
+		t.Errorf("error: got %s, want %s", got, want)
+	}
+
+	if _, err = LookupZone(""); err == nil {
+		t.Errorf("empty input: got nil error")
+	}
+}
@@ -0,0 +1,374 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// MinZoneOffset is the minimum valid UTC offset of a zone (UTC-12:00).
+	MinZoneOffset = -12 * time.Hour
+	// MaxZoneOffset is the maximum valid UTC offset of a zone (UTC+14:00).
+	MaxZoneOffset = 14 * time.Hour
+)
+
+// ParseOffsetZone parses the fixed UTC offset zone.
+// The following forms are accepted:
+//
+//	Z, UTC, GMT                   -> UTC
+//	UTC+05:30, UTC+0530, UTC+5:30 -> UTC+05:30
+//	GMT-3, GMT-03:00              -> UTC-03:00
+//	+0100, +01:00, +01            -> UTC+01:00
+//
+// The prefix is case-insensitive. Without the prefix, the hours must have two digits.
+// The offset must be in range of MinZoneOffset and MaxZoneOffset.
+// The returned location is named after the offset, i.e. "UTC+05:30",
+// except for the zero offset which returns time.UTC.
+func ParseOffsetZone(s string) (*time.Location, error) {
+	offset, err := parseZoneOffset(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid offset zone %q: %w", s, err)
+	}
+	if offset == 0 {
+		return time.UTC, nil
+	}
+	return time.FixedZone(FormatOffsetZoneName(offset), int(offset/time.Second)), nil
+}
+
+// FormatOffsetZoneName returns the name of the fixed offset zone, i.e. "UTC+05:30".
+func FormatOffsetZoneName(offset time.Duration) string {
+	if offset == 0 {
+		return "UTC"
+	}
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	return fmt.Sprintf("UTC%c%02d:%02d", sign, int(offset/time.Hour), int(offset%time.Hour/time.Minute))
+}
+
+func parseZoneOffset(s string) (time.Duration, error) {
+	if s == "Z" || s == "z" {
+		return 0, nil
+	}
+	var prefixed bool
+	for _, prefix := range []string{"UTC", "GMT", "UT"} {
+		if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+			s = s[len(prefix):]
+			prefixed = true
+			break
+		}
+	}
+	if prefixed && s == "" {
+		return 0, nil
+	}
+	if s == "" {
+		return 0, errors.New("empty offset")
+	}
+
+	var sign time.Duration
+	switch s[0] {
+	case '+':
+		sign = 1
+	case '-':
+		sign = -1
+	default:
+		return 0, errors.New("missing offset sign")
+	}
+	s = s[1:]
+
+	var hh, mm string
+	switch {
+	case len(s) == 5 && s[2] == ':':
+		hh, mm = s[:2], s[3:]
+	case len(s) == 4 && s[1] == ':' && prefixed:
+		hh, mm = s[:1], s[2:]
+	case len(s) == 4:
+		hh, mm = s[:2], s[2:]
+	case len(s) == 2:
+		hh = s
+	case len(s) == 1 && prefixed:
+		hh = s
+	default:
+		return 0, errors.New("malformed offset")
+	}
+
+	hours, ok := parseDigits(hh)
+	if !ok {
+		return 0, errors.New("malformed offset hours")
+	}
+	var minutes int
+	if mm != "" {
+		if minutes, ok = parseDigits(mm); !ok {
+			return 0, errors.New("malformed offset minutes")
+		}
+		if minutes > 59 {
+			return 0, errors.New("offset minutes out of range")
+		}
+	}
+
+	offset := sign * (time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute)
+	if offset < MinZoneOffset || offset > MaxZoneOffset {
+		return 0, errors.New("offset out of range")
+	}
+	return offset, nil
+}
+
+// parseDigits parses the non-empty string of decimal digits.
+func parseDigits(s string) (int, bool) {
+	if s == "" {
+		return 0, false
+	}
+	var n int
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, false
+		}
+		n = n*10 + int(s[i]-'0')
+	}
+	return n, true
+}
+
+// ParsePOSIXZone parses the POSIX TZ string, i.e. "CET-1CEST,M3.5.0,M10.5.0/3".
+// Note that POSIX offsets are west of UTC, thus "EST5" is UTC-05:00.
+// The returned location is named after the input string.
+func ParsePOSIXZone(s string) (*time.Location, error) {
+	p := posixParser{s: s}
+	stdName, stdOffset, err := p.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid POSIX zone %q: %w", s, err)
+	}
+	return time.LoadLocationFromTZData(s, posixTZData(s, stdName, stdOffset))
+}
+
+// ParseZone parses the zone given by the user or a partner API.
+// The input is tried in the following order:
+//
+//  1. IANA zone name, i.e. "Europe/Berlin" (see LoadLocation).
+//  2. Fixed UTC offset, i.e. "UTC+05:30", "GMT-3" or "Z" (see ParseOffsetZone).
+//  3. POSIX TZ string, i.e. "EST5EDT,M3.2.0,M11.1.0" (see ParsePOSIXZone).
+//
+// Note that offsets are tried before POSIX strings, thus "GMT-3" is UTC-03:00,
+// even though it would be UTC+03:00 if read as a POSIX TZ string.
+// The input which looks like an offset, i.e. "GMT+15", is never read as a POSIX TZ string,
+// and its ParseOffsetZone error is returned instead.
+func ParseZone(s string) (*time.Location, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "Local" {
+		return nil, fmt.Errorf("invalid zone %q", s)
+	}
+	if loc, err := LoadLocation(s); err == nil {
+		return loc, nil
+	}
+	loc, err := ParseOffsetZone(s)
+	if err == nil {
+		return loc, nil
+	}
+	if looksLikeOffset(s) {
+		return nil, err
+	}
+	if loc, err := ParsePOSIXZone(s); err == nil {
+		return loc, nil
+	}
+	return nil, fmt.Errorf("invalid zone %q: not an IANA name, UTC offset or POSIX TZ string", s)
+}
+
+// looksLikeOffset reports whether s is shaped as the UTC offset, i.e. "Z", "+01:00", or "UTC", "GMT" or "UT"
+// followed by the offset sign, so that the invalid offsets are not read as the POSIX TZ strings of the opposite sign.
+func looksLikeOffset(s string) bool {
+	if s == "Z" || s == "z" || s[0] == '+' || s[0] == '-' {
+		return true
+	}
+	for _, prefix := range []string{"UTC", "GMT", "UT"} {
+		if len(s) > len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+			return s[len(prefix)] == '+' || s[len(prefix)] == '-'
+		}
+	}
+	return false
+}
+
+// posixParser validates the POSIX TZ string:
+//
+//	std offset [dst [offset] [,start[/time],end[/time]]]
+type posixParser struct {
+	s string
+}
+
+func (p *posixParser) parse() (string, time.Duration, error) {
+	stdName, err := p.name()
+	if err != nil {
+		return "", 0, err
+	}
+	stdOffset, err := p.offset(24)
+	if err != nil {
+		return "", 0, err
+	}
+	if p.s == "" {
+		return stdName, stdOffset, nil
+	}
+	if _, err = p.name(); err != nil {
+		return "", 0, err
+	}
+	if p.s != "" && p.s[0] != ',' {
+		if _, err = p.offset(24); err != nil {
+			return "", 0, err
+		}
+	}
+	if p.s == "" {
+		return stdName, stdOffset, nil
+	}
+	for i := 0; i < 2; i++ {
+		if p.s == "" || p.s[0] != ',' {
+			return "", 0, errors.New("malformed rule")
+		}
+		p.s = p.s[1:]
+		if err = p.date(); err != nil {
+			return "", 0, err
+		}
+		if p.s != "" && p.s[0] == '/' {
+			p.s = p.s[1:]
+			if _, err = p.offset(167); err != nil {
+				return "", 0, err
+			}
+		}
+	}
+	if p.s != "" {
+		return "", 0, fmt.Errorf("unexpected %q", p.s)
+	}
+	return stdName, stdOffset, nil
+}
+
+func (p *posixParser) name() (string, error) {
+	if p.s != "" && p.s[0] == '<' {
+		end := strings.IndexByte(p.s, '>')
+		if end < 4 {
+			return "", errors.New("malformed quoted zone name")
+		}
+		name := p.s[1:end]
+		p.s = p.s[end+1:]
+		return name, nil
+	}
+	var i int
+	for i < len(p.s) && (p.s[i] >= 'a' && p.s[i] <= 'z' || p.s[i] >= 'A' && p.s[i] <= 'Z') {
+		i++
+	}
+	if i < 3 {
+		return "", errors.New("zone name must have at least three letters")
+	}
+	name := p.s[:i]
+	p.s = p.s[i:]
+	return name, nil
+}
+
+// offset parses [+-]hh[:mm[:ss]] and returns it as a POSIX offset (west of UTC).
+func (p *posixParser) offset(maxHours int) (time.Duration, error) {
+	sign := time.Duration(1)
+	if p.s != "" && (p.s[0] == '+' || p.s[0] == '-') {
+		if p.s[0] == '-' {
+			sign = -1
+		}
+		p.s = p.s[1:]
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		if i > 0 {
+			if p.s == "" || p.s[0] != ':' {
+				break
+			}
+			p.s = p.s[1:]
+		}
+		var j int
+		for j < len(p.s) && j < 3 && p.s[j] >= '0' && p.s[j] <= '9' {
+			j++
+		}
+		n, ok := parseDigits(p.s[:j])
+		if !ok || (i == 0 && n > maxHours) || (i > 0 && n > 59) {
+			return 0, errors.New("malformed offset")
+		}
+		p.s = p.s[j:]
+		d += time.Duration(n) * unit
+	}
+	return sign * d, nil
+}
+
+// date parses the Jn, n or Mm.w.d rule date.
+func (p *posixParser) date() error {
+	number := func(lo, hi int) error {
+		var j int
+		for j < len(p.s) && p.s[j] >= '0' && p.s[j] <= '9' {
+			j++
+		}
+		n, ok := parseDigits(p.s[:j])
+		if !ok || n < lo || n > hi {
+			return errors.New("malformed rule date")
+		}
+		p.s = p.s[j:]
+		return nil
+	}
+	switch {
+	case p.s == "":
+		return errors.New("missing rule date")
+	case p.s[0] == 'J':
+		p.s = p.s[1:]
+		return number(1, 365)
+	case p.s[0] == 'M':
+		p.s = p.s[1:]
+		for i, bounds := range [][2]int{{1, 12}, {1, 5}, {0, 6}} {
+			if i > 0 {
+				if p.s == "" || p.s[0] != '.' {
+					return errors.New("malformed rule date")
+				}
+				p.s = p.s[1:]
+			}
+			if err := number(bounds[0], bounds[1]); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return number(0, 365)
+	}
+}
+
+// posixTZData builds the TZif (version 2) data without transitions,
+// whose footer is the POSIX TZ string, which describes all the time.
+func posixTZData(tz, stdName string, stdOffset time.Duration) []byte {
+	abbrev := append([]byte(stdName), 0)
+
+	var b bytes.Buffer
+	block := func() {
+		b.WriteString("TZif2")
+		b.Write(make([]byte, 15))
+		// isutcnt, isstdcnt, leapcnt, timecnt, typecnt, charcnt
+		for _, n := range []uint32{0, 0, 0, 0, 1, uint32(len(abbrev))} {
+			_ = binary.Write(&b, binary.BigEndian, n)
+		}
+		// utoff, isdst, desigidx
+		_ = binary.Write(&b, binary.BigEndian, int32(-stdOffset/time.Second))
+		b.Write([]byte{0, 0})
+		b.Write(abbrev)
+	}
+	block() // version 1 data
+	block() // version 2 data
+	b.WriteString("\n" + tz + "\n")
+	return b.Bytes()
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseOffsetZone(t *testing.T) {
+	for _, test := range []struct {
+		input      string
+		wantName   string
+		wantOffset time.Duration
+		wantErr    bool
+	}{
+		{input: "Z", wantName: "UTC"},
+		{input: "utc", wantName: "UTC"},
+		{input: "UTC+05:30", wantName: "UTC+05:30", wantOffset: 5*time.Hour + 30*time.Minute},
+		{input: "UTC+0530", wantName: "UTC+05:30", wantOffset: 5*time.Hour + 30*time.Minute},
+		{input: "UTC+5:30", wantName: "UTC+05:30", wantOffset: 5*time.Hour + 30*time.Minute},
+		{input: "GMT-3", wantName: "UTC-03:00", wantOffset: -3 * time.Hour},
+		{input: "+0100", wantName: "UTC+01:00", wantOffset: time.Hour},
+		{input: "-09:30", wantName: "UTC-09:30", wantOffset: -9*time.Hour - 30*time.Minute},
+		{input: "+14", wantName: "UTC+14:00", wantOffset: 14 * time.Hour},
+		{input: "+1", wantErr: true},
+		{input: "0100", wantErr: true},
+		{input: "UTC+15", wantErr: true},
+		{input: "-13:00", wantErr: true},
+		{input: "+01:60", wantErr: true},
+		{input: "UTC+", wantErr: true},
+		{input: "Europe/Berlin", wantErr: true},
+	} {
+		t.Run(test.input, func(t *testing.T) {
+			loc, err := ParseOffsetZone(test.input)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("got location %v, want error", loc)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if loc.String() != test.wantName {
+				t.Errorf("name: got %s, want %s", loc, test.wantName)
+			}
+			if _, offset := time.Now().In(loc).Zone(); time.Duration(offset)*time.Second != test.wantOffset {
+				t.Errorf("offset: got %ds, want %s", offset, test.wantOffset)
+			}
+		})
+	}
+}
+
+func TestParseZone(t *testing.T) {
+	winter := time.Date(2023, time.January, 15, 12, 0, 0, 0, time.UTC)
+	summer := time.Date(2023, time.July, 15, 12, 0, 0, 0, time.UTC)
+	for _, test := range []struct {
+		input        string
+		wantWinter   int
+		wantSummer   int
+		wantSummerTZ string
+	}{
+		{input: "Europe/Berlin", wantWinter: 3600, wantSummer: 7200, wantSummerTZ: "CEST"},
+		{input: "GMT-3", wantWinter: -3 * 3600, wantSummer: -3 * 3600, wantSummerTZ: "UTC-03:00"},
+		{input: "CET-1CEST,M3.5.0,M10.5.0/3", wantWinter: 3600, wantSummer: 7200, wantSummerTZ: "CEST"},
+		{input: "<+0330>-3:30", wantWinter: 12600, wantSummer: 12600, wantSummerTZ: "+0330"},
+		{input: "AEST-10AEDT,M10.1.0,M4.1.0/3", wantWinter: 11 * 3600, wantSummer: 10 * 3600, wantSummerTZ: "AEST"},
+	} {
+		t.Run(test.input, func(t *testing.T) {
+			loc, err := ParseZone(test.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, offset := winter.In(loc).Zone(); offset != test.wantWinter {
+				t.Errorf("winter offset: got %d, want %d", offset, test.wantWinter)
+			}
+			name, offset := summer.In(loc).Zone()
+			if offset != test.wantSummer {
+				t.Errorf("summer offset: got %d, want %d", offset, test.wantSummer)
+			}
+			if name != test.wantSummerTZ {
+				t.Errorf("summer zone: got %s, want %s", name, test.wantSummerTZ)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "Local", "Mars/Olympus", "EST5EDT,M3", "AB5"} {
+		if loc, err := ParseZone(input); err == nil {
+			t.Errorf("%q: got location %v, want error", input, loc)
+		}
+	}
+
+	// The invalid offsets are not read as the POSIX TZ strings of the opposite sign.
+	for _, input := range []string{"GMT+15", "UTC+14:01", "UTC+1:3", "utc-25", "+1:30", "UT+5:60"} {
+		loc, err := ParseZone(input)
+		if err == nil {
+			t.Errorf("%q: got location %v, want error", input, loc)
+		} else if !strings.Contains(err.Error(), "invalid offset zone") {
+			t.Errorf("%q: got error %v, want the offset zone error", input, err)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/blockysource/go-pkg/times/internal/tzlocal"
+	"github.com/blockysource/go-pkg/times/internal/tzzones"
+)
+
+// Countries returns the ISO 3166 country codes mapped to the country names.
+func Countries() map[string]string {
+	res := make(map[string]string, len(tzzones.Countries))
+	for code, name := range tzzones.Countries {
+		res[code] = name
+	}
+	return res
+}
+
+// CountryZones returns the sorted IANA names of the zones used by the country of the ISO 3166 code, i.e. "DE".
+func CountryZones(country string) []string {
+	country = strings.ToUpper(country)
+	var res []string
+	for _, z := range tzzones.Zones {
+		for _, c := range z.Countries {
+			if c == country {
+				res = append(res, z.Name)
+				break
+			}
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// WindowsToIANA returns the IANA name of the Windows time zone, i.e. "W. Europe Standard Time".
+func WindowsToIANA(name string) (string, bool) {
+	iana, ok := tzlocal.WinTZtoIANA[name]
+	return iana, ok
+}
+
+// IANAToWindows returns the Windows name of the IANA time zone, i.e. "Europe/Berlin".
+// The links, like "Europe/Kiev", are resolved to their target zones, if they are not mapped directly.
+func IANAToWindows(name string) (string, bool) {
+	if win, ok := tzlocal.IANAtoWinTZ[name]; ok {
+		return win, true
+	}
+	win, ok := tzlocal.IANAtoWinTZ[tzzones.Links[name]]
+	return win, ok
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 The Blocky Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package times
+
+import "testing"
+
+func TestCountryZones(t *testing.T) {
+	got := CountryZones("ar")
+	if len(got) == 0 || got[0] != "America/Argentina/Buenos_Aires" {
+		t.Errorf("CountryZones(ar): got %v", got)
+	}
+	if got = CountryZones("XX"); len(got) != 0 {
+		t.Errorf("CountryZones(XX): got %v", got)
+	}
+	if name := Countries()["JP"]; name != "Japan" {
+		t.Errorf("Countries()[JP]: got %q", name)
+	}
+}
+
+func TestWindowsMapping(t *testing.T) {
+	if got, ok := WindowsToIANA("W. Europe Standard Time"); !ok || got != "Europe/Berlin" {
+		t.Errorf("WindowsToIANA: got %q, %v", got, ok)
+	}
+	if got, ok := IANAToWindows("Europe/Berlin"); !ok || got != "W. Europe Standard Time" {
+		t.Errorf("IANAToWindows: got %q, %v", got, ok)
+	}
+	if _, ok := IANAToWindows("Mars/Olympus"); ok {
+		t.Error("IANAToWindows of unknown zone succeeded")
+	}
+}